@@ -0,0 +1,148 @@
+// Package router contains the Go benchmarks of the router's request lookup,
+// they're running without a network in order to measure only the routing.
+//
+// $ cd _benchmarks/iris-router
+// $ go test -run=^$ -bench=. -benchmem
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+)
+
+var staticRoutes = []string{
+	"/",
+	"/about",
+	"/contact",
+	"/authorizations",
+	"/applications/clients",
+	"/events",
+	"/feeds",
+	"/notifications",
+	"/gists/public",
+	"/gists/starred",
+	"/issues",
+	"/user/repos",
+	"/user/emails",
+	"/user/followers",
+	"/user/following",
+	"/user/keys",
+	"/user/orgs",
+	"/user/starred",
+	"/user/subscriptions",
+	"/user/teams",
+	"/legal/privacy",
+	"/legal/terms",
+}
+
+var dynamicRoutes = []string{
+	"/users/{user}",
+	"/users/{user}/repos",
+	"/users/{user}/followers",
+	"/users/{user}/gists",
+	"/repos/{owner}/{repo}",
+	"/repos/{owner}/{repo}/issues",
+	"/repos/{owner}/{repo}/issues/{number:int}",
+	"/repos/{owner}/{repo}/issues/{number:int}/comments",
+	"/repos/{owner}/{repo}/pulls/{number:int}",
+	"/repos/{owner}/{repo}/git/refs/{ref:path}",
+	"/orgs/{org}/members/{user}",
+	"/gists/{id}",
+	"/gists/{id}/star",
+	"/teams/{id:int min(1)}/members",
+	"/static/{file:path}",
+}
+
+// discardWriter is a minimal http.ResponseWriter which keeps nothing,
+// so the benchmarks are not measuring the allocations of a recorder.
+type discardWriter struct{ header http.Header }
+
+func (w *discardWriter) Header() http.Header         { return w.header }
+func (w *discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardWriter) WriteHeader(int)             {}
+
+func newApp() *iris.Application {
+	app := iris.New()
+	noop := func(ctx context.Context) {}
+
+	for _, path := range staticRoutes {
+		app.Get(path, noop)
+	}
+
+	for _, path := range dynamicRoutes {
+		app.Get(path, noop)
+	}
+
+	if err := app.Build(); err != nil {
+		panic(err)
+	}
+
+	return app
+}
+
+func benchmarkRequests(b *testing.B, paths ...string) {
+	app := newApp()
+	w := &discardWriter{header: make(http.Header)}
+
+	requests := make([]*http.Request, len(paths))
+	for i, path := range paths {
+		requests[i] = httptest.NewRequest("GET", path, nil)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r := range requests {
+			app.ServeHTTP(w, r)
+		}
+	}
+}
+
+func BenchmarkStaticRoot(b *testing.B) {
+	benchmarkRequests(b, "/")
+}
+
+func BenchmarkStatic(b *testing.B) {
+	benchmarkRequests(b, "/user/subscriptions")
+}
+
+func BenchmarkStaticAll(b *testing.B) {
+	benchmarkRequests(b, staticRoutes...)
+}
+
+func BenchmarkParam(b *testing.B) {
+	benchmarkRequests(b, "/users/kataras")
+}
+
+func BenchmarkParams3(b *testing.B) {
+	benchmarkRequests(b, "/repos/kataras/iris/issues/42")
+}
+
+func BenchmarkParamsEvaluated(b *testing.B) {
+	benchmarkRequests(b, "/teams/42/members")
+}
+
+func BenchmarkWildcard(b *testing.B) {
+	benchmarkRequests(b, "/static/css/bootstrap/bootstrap.min.css")
+}
+
+func BenchmarkDynamicAll(b *testing.B) {
+	benchmarkRequests(b,
+		"/users/kataras",
+		"/users/kataras/repos",
+		"/repos/kataras/iris",
+		"/repos/kataras/iris/issues/42/comments",
+		"/repos/kataras/iris/git/refs/heads/master",
+		"/orgs/iris-contrib/members/kataras",
+		"/gists/1234/star",
+		"/teams/42/members",
+	)
+}
+
+func BenchmarkNotFound(b *testing.B) {
+	benchmarkRequests(b, "/users/kataras/unknown", "/unknown")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/kataras/iris"
 )
@@ -146,6 +147,36 @@ func main() {
 		ctx.Writef("name: %s | level: %s", ctx.Params().Get("name"), ctx.Params().Get("level"))
 	})
 
+	// routes that are sharing the same path but with different macro types or functions can coexist,
+	// if a path parameter's value is not valid for a route then the router tries the next one,
+	// a 404 not found is fired only if none of them matched.
+	// http://localhost:8080/user/42
+	app.Get("/user/{id:int}", func(ctx iris.Context) {
+		id, _ := ctx.Params().GetInt("id")
+		ctx.Writef("user by id: %d", id)
+	})
+	// http://localhost:8080/user/kataras
+	app.Get("/user/{username:alphabetical}", func(ctx iris.Context) {
+		ctx.Writef("user by username: %s", ctx.Params().Get("username"))
+	})
+
+	// Register a custom named path parameter type,
+	// with its own evaluator and an optional converter,
+	// the converted value can be retrieved by `ctx.Params().GetValue`.
+	// Param functions can be registered to that type too, through the returned macro's `RegisterFunc`.
+	app.Macros().Register("date", func(paramValue string) bool {
+		_, err := time.Parse("2006-01-02", paramValue)
+		return err == nil
+	}, func(paramValue string) (time.Time, error) {
+		return time.Parse("2006-01-02", paramValue)
+	})
+
+	// http://localhost:8080/archive/2017-12-05
+	app.Get("/archive/{day:date}", func(ctx iris.Context) {
+		day := ctx.Params().GetValue("day").(time.Time)
+		ctx.Writef("archive of %s", day.Weekday())
+	})
+
 	app.Get("/lowercase/static", func(ctx iris.Context) {
 		ctx.Writef("static and dynamic paths are not conflicted anymore!")
 	})
@@ -20,6 +20,14 @@ func main() {
 		ctx.StatusCode(500)
 	})
 
+	// error code handlers per Party,
+	// GET /api/notfound will render the JSON error
+	// instead of the root's (default) text.
+	api := app.Party("/api")
+	api.OnErrorCode(iris.StatusNotFound, func(ctx iris.Context) {
+		ctx.JSON(iris.Map{"error": "resource not found"})
+	})
+
 	app.Get("/u/{firstname:alphabetical}", func(ctx iris.Context) {
 		ctx.Writef("Hello %s", ctx.Params().Get("firstname"))
 	})
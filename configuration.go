@@ -13,6 +13,7 @@ import (
 
 	"github.com/kataras/iris/context"
 	"github.com/kataras/iris/core/errors"
+	"github.com/kataras/iris/middleware/rewrite"
 )
 
 const globalConfigurationKeyword = "~"
@@ -290,6 +291,13 @@ var WithOptimizations = func(app *Application) {
 	app.config.EnableOptimizations = true
 }
 
+// WithRouteWarningsAsErrors enables the EnableRouteWarningsAsErrors setting.
+//
+// See `Configuration`.
+var WithRouteWarningsAsErrors = func(app *Application) {
+	app.config.EnableRouteWarningsAsErrors = true
+}
+
 // WithFireMethodNotAllowed enanbles the FireMethodNotAllowed setting.
 //
 // See `Configuration`.
@@ -297,6 +305,41 @@ var WithFireMethodNotAllowed = func(app *Application) {
 	app.config.FireMethodNotAllowed = true
 }
 
+// WithAutoHead enables the EnableAutoHead setting.
+//
+// See `Configuration`.
+var WithAutoHead = func(app *Application) {
+	app.config.EnableAutoHead = true
+}
+
+// WithAutoOptions enables the EnableAutoOptions setting.
+//
+// See `Configuration`.
+var WithAutoOptions = func(app *Application) {
+	app.config.EnableAutoOptions = true
+}
+
+// WithCaseInsensitivePaths enables the EnableCaseInsensitivePaths setting.
+//
+// See `Configuration`.
+var WithCaseInsensitivePaths = func(app *Application) {
+	app.config.EnableCaseInsensitivePaths = true
+}
+
+// WithPathNormalization enables the EnablePathNormalization setting.
+//
+// See `Configuration`.
+var WithPathNormalization = func(app *Application) {
+	app.config.EnablePathNormalization = true
+}
+
+// WithCanonicalPathRedirect enables the EnableCanonicalPathRedirect setting.
+//
+// See `Configuration`.
+var WithCanonicalPathRedirect = func(app *Application) {
+	app.config.EnableCanonicalPathRedirect = true
+}
+
 // WithTimeFormat sets the TimeFormat setting.
 //
 // See `Configuration`.
@@ -351,6 +394,15 @@ func WithoutRemoteAddrHeader(headerName string) Configurator {
 	}
 }
 
+// WithRewrites adds URL rewrite and redirect rules to the Rewrites setting.
+//
+// See `Configuration`.
+func WithRewrites(rules ...rewrite.Rule) Configurator {
+	return func(app *Application) {
+		app.config.Rewrites = append(app.config.Rewrites, rules...)
+	}
+}
+
 // WithOtherValue adds a value based on a key to the Other setting.
 //
 // See `Configuration`.
@@ -403,7 +455,10 @@ type Configuration struct {
 	// DisablePathCorrection corrects and redirects the requested path to the registered path
 	// for example, if /home/ path is requested but no handler for this Route found,
 	// then the Router checks if /home handler exists, if yes,
-	// (permant)redirects the client to the correct path /home
+	// (permant)redirects the client to the correct path /home,
+	// the 308 status code is used for methods other than GET and HEAD in order to keep the request's method.
+	//
+	// It's the default policy of the Parties, see `Party#TrailingSlash` for a per-Party policy.
 	//
 	// Defaults to false.
 	DisablePathCorrection bool `json:"disablePathCorrection,omitempty" yaml:"DisablePathCorrection" toml:"DisablePathCorrection"`
@@ -426,10 +481,51 @@ type Configuration struct {
 	//
 	// Defaults to false.
 	EnableOptimizations bool `json:"enableOptimizations,omitempty" yaml:"EnableOptimizations" toml:"EnableOptimizations"`
+	// EnableRouteWarningsAsErrors if it's true then the `Build` fails when the routes' diagnostics
+	// report any warnings, i.e routes that are shadowed by other routes
+	// or path parameters that can never match, useful for CI.
+	// If it's false then the warnings are just logged.
+	//
+	// Look `router.Diagnose` for more.
+	//
+	// Defaults to false.
+	EnableRouteWarningsAsErrors bool `json:"enableRouteWarningsAsErrors,omitempty" yaml:"EnableRouteWarningsAsErrors" toml:"EnableRouteWarningsAsErrors"`
 	// FireMethodNotAllowed if it's true router checks for StatusMethodNotAllowed(405) and
 	//  fires the 405 error instead of 404
 	// Defaults to false.
 	FireMethodNotAllowed bool `json:"fireMethodNotAllowed,omitempty" yaml:"FireMethodNotAllowed" toml:"FireMethodNotAllowed"`
+	// EnableAutoHead if it's true then the router serves the HEAD requests
+	// through the GET routes' handlers, when a HEAD route is not registered,
+	// the response's headers are kept but its body is discarded.
+	//
+	// Defaults to false.
+	EnableAutoHead bool `json:"enableAutoHead,omitempty" yaml:"EnableAutoHead" toml:"EnableAutoHead"`
+	// EnableAutoOptions if it's true then the router answers the OPTIONS requests,
+	// when an OPTIONS route is not registered, with an "Allow" header
+	// which contains the http methods that the requested path can be served by.
+	//
+	// Defaults to false.
+	EnableAutoOptions bool `json:"enableAutoOptions,omitempty" yaml:"EnableAutoOptions" toml:"EnableAutoOptions"`
+	// EnableCaseInsensitivePaths if it's true then the request paths that are not registered
+	// are matched against the routes case-insensitively, i.e "/Products/ABC" is served by the "/products/{id}",
+	// the path parameters' values are kept in their original case.
+	// It can be set per Party too, see `Party#PathMatching`.
+	//
+	// Defaults to false.
+	EnableCaseInsensitivePaths bool `json:"enableCaseInsensitivePaths,omitempty" yaml:"EnableCaseInsensitivePaths" toml:"EnableCaseInsensitivePaths"`
+	// EnablePathNormalization if it's true then the request paths that are not registered
+	// are normalized to the Unicode's NFC form, after their percent-decoding, before they're matched against the routes.
+	// It can be set per Party too, see `Party#PathMatching`.
+	//
+	// Defaults to false.
+	EnablePathNormalization bool `json:"enablePathNormalization,omitempty" yaml:"EnablePathNormalization" toml:"EnablePathNormalization"`
+	// EnableCanonicalPathRedirect if it's true then the requests that are matched
+	// case-insensitively or after their normalization are redirected to the path of the route's registered form,
+	// instead of being served directly.
+	// It can be set per Party too, see `Party#PathMatching`.
+	//
+	// Defaults to false.
+	EnableCanonicalPathRedirect bool `json:"enableCanonicalPathRedirect,omitempty" yaml:"EnableCanonicalPathRedirect" toml:"EnableCanonicalPathRedirect"`
 
 	// DisableBodyConsumptionOnUnmarshal manages the reading behavior of the context's body readers/binders.
 	// If setted to true then it
@@ -503,6 +599,16 @@ type Configuration struct {
 	// Look `context.RemoteAddr()` for more.
 	RemoteAddrHeaders map[string]bool `json:"remoteAddrHeaders,omitempty" yaml:"RemoteAddrHeaders" toml:"RemoteAddrHeaders"`
 
+	// Rewrites are the URL rewrite and redirect rules,
+	// they are compiled on `Build` and the first rule that matches a request
+	// redirects it or rewrites its path before the router.
+	// An invalid rule is reported as a build error.
+	//
+	// Look the `middleware/rewrite` package for more.
+	//
+	// Defaults to empty.
+	Rewrites []rewrite.Rule `json:"rewrites,omitempty" yaml:"Rewrites" toml:"Rewrites"`
+
 	// Other are the custom, dynamic options, can be empty.
 	// This field used only by you to set any app's options you want
 	// or by custom adaptors, it's a way to simple communicate between your adaptors (if any)
@@ -542,11 +648,42 @@ func (c Configuration) GetEnableOptimizations() bool {
 	return c.EnableOptimizations
 }
 
+// GetEnableRouteWarningsAsErrors returns the Configuration#EnableRouteWarningsAsErrors,
+// if true then the routes' diagnostics warnings are failing the `Build`.
+func (c Configuration) GetEnableRouteWarningsAsErrors() bool {
+	return c.EnableRouteWarningsAsErrors
+}
+
 // GetFireMethodNotAllowed returns the Configuration#FireMethodNotAllowed.
 func (c Configuration) GetFireMethodNotAllowed() bool {
 	return c.FireMethodNotAllowed
 }
 
+// GetEnableAutoHead returns the Configuration#EnableAutoHead.
+func (c Configuration) GetEnableAutoHead() bool {
+	return c.EnableAutoHead
+}
+
+// GetEnableAutoOptions returns the Configuration#EnableAutoOptions.
+func (c Configuration) GetEnableAutoOptions() bool {
+	return c.EnableAutoOptions
+}
+
+// GetEnableCaseInsensitivePaths returns the Configuration#EnableCaseInsensitivePaths.
+func (c Configuration) GetEnableCaseInsensitivePaths() bool {
+	return c.EnableCaseInsensitivePaths
+}
+
+// GetEnablePathNormalization returns the Configuration#EnablePathNormalization.
+func (c Configuration) GetEnablePathNormalization() bool {
+	return c.EnablePathNormalization
+}
+
+// GetEnableCanonicalPathRedirect returns the Configuration#EnableCanonicalPathRedirect.
+func (c Configuration) GetEnableCanonicalPathRedirect() bool {
+	return c.EnableCanonicalPathRedirect
+}
+
 // GetDisableBodyConsumptionOnUnmarshal returns the Configuration#GetDisableBodyConsumptionOnUnmarshal,
 // manages the reading behavior of the context's body readers/binders.
 // If returns true then the body consumption by the `context.UnmarshalBody/ReadJSON/ReadXML`
@@ -619,6 +756,11 @@ func (c Configuration) GetRemoteAddrHeaders() map[string]bool {
 	return c.RemoteAddrHeaders
 }
 
+// GetRewrites returns the Configuration#Rewrites, the URL rewrite and redirect rules.
+func (c Configuration) GetRewrites() []rewrite.Rule {
+	return c.Rewrites
+}
+
 // GetOther returns the Configuration#Other map.
 func (c Configuration) GetOther() map[string]interface{} {
 	return c.Other
@@ -664,10 +806,34 @@ func WithConfiguration(c Configuration) Configurator {
 			main.EnableOptimizations = v
 		}
 
+		if v := c.EnableRouteWarningsAsErrors; v {
+			main.EnableRouteWarningsAsErrors = v
+		}
+
 		if v := c.FireMethodNotAllowed; v {
 			main.FireMethodNotAllowed = v
 		}
 
+		if v := c.EnableAutoHead; v {
+			main.EnableAutoHead = v
+		}
+
+		if v := c.EnableAutoOptions; v {
+			main.EnableAutoOptions = v
+		}
+
+		if v := c.EnableCaseInsensitivePaths; v {
+			main.EnableCaseInsensitivePaths = v
+		}
+
+		if v := c.EnablePathNormalization; v {
+			main.EnablePathNormalization = v
+		}
+
+		if v := c.EnableCanonicalPathRedirect; v {
+			main.EnableCanonicalPathRedirect = v
+		}
+
 		if v := c.DisableBodyConsumptionOnUnmarshal; v {
 			main.DisableBodyConsumptionOnUnmarshal = v
 		}
@@ -709,6 +875,10 @@ func WithConfiguration(c Configuration) Configurator {
 			}
 		}
 
+		if v := c.Rewrites; len(v) > 0 {
+			main.Rewrites = append(main.Rewrites, v...)
+		}
+
 		if v := c.Other; len(v) > 0 {
 			if main.Other == nil {
 				main.Other = make(map[string]interface{})
@@ -729,6 +899,8 @@ func DefaultConfiguration() Configuration {
 		DisablePathCorrection:             false,
 		EnablePathEscape:                  false,
 		FireMethodNotAllowed:              false,
+		EnableAutoHead:                    false,
+		EnableAutoOptions:                 false,
 		DisableBodyConsumptionOnUnmarshal: false,
 		DisableAutoFireStatusCode:         false,
 		TimeFormat:                        "Mon, Jan 02 2006 15:04:05 GMT",
@@ -131,6 +131,14 @@ RemoteAddrHeaders:
   X-Forwarded-For: true
   CF-Connecting-IP: true
 
+Rewrites:
+  - Source: "/docs/{version:int}/{page:path}"
+    Target: "/documentation/v{version}/{page}"
+  - Source: "^/blog/(.+)\\.html$"
+    Target: "/posts/$1"
+    Mode: "rewrite"
+    Hosts: ["mydomain.com", "*.mydomain.com"]
+
 Other:
   MyServerName: "Iris: https://github.com/kataras/iris"
 `
@@ -192,6 +200,18 @@ Other:
 		}
 	}
 
+	if expected, got := 2, len(c.Rewrites); expected != got {
+		t.Fatalf("error on TestConfigurationYAML: Expected %d Rewrites but got %d", expected, got)
+	}
+
+	if r := c.Rewrites[1]; r.Source != `^/blog/(.+)\.html$` || r.Target != "/posts/$1" || r.Mode != "rewrite" || len(r.Hosts) != 2 {
+		t.Fatalf("error on TestConfigurationYAML: Unexpected Rewrites[1] %#v", r)
+	}
+
+	if err := app.Build(); err != nil {
+		t.Fatalf("error on TestConfigurationYAML: Expected the Rewrites to be valid but got %v", err)
+	}
+
 	if len(c.Other) == 0 {
 		t.Fatalf("error on TestConfigurationYAML: Expected Other to be filled")
 	}
@@ -229,6 +249,16 @@ Charset = "UTF-8"
     X-Forwarded-For = true
     CF-Connecting-IP = true
 
+[[Rewrites]]
+	Source = "/docs/{version:int}/{page:path}"
+	Target = "/documentation/v{version}/{page}"
+
+[[Rewrites]]
+	Source = '^/blog/(.+)\.html$'
+	Target = "/posts/$1"
+	Mode = "rewrite"
+	Hosts = ["mydomain.com", "*.mydomain.com"]
+
 [Other]
 	# Indentation (tabs and/or spaces) is allowed but not required
 	MyServerName = "Iris: https://github.com/kataras/iris"
@@ -292,6 +322,18 @@ Charset = "UTF-8"
 		}
 	}
 
+	if expected, got := 2, len(c.Rewrites); expected != got {
+		t.Fatalf("error on TestConfigurationTOML: Expected %d Rewrites but got %d", expected, got)
+	}
+
+	if r := c.Rewrites[1]; r.Source != `^/blog/(.+)\.html$` || r.Target != "/posts/$1" || r.Mode != "rewrite" || len(r.Hosts) != 2 {
+		t.Fatalf("error on TestConfigurationTOML: Unexpected Rewrites[1] %#v", r)
+	}
+
+	if err := app.Build(); err != nil {
+		t.Fatalf("error on TestConfigurationTOML: Expected the Rewrites to be valid but got %v", err)
+	}
+
 	if len(c.Other) == 0 {
 		t.Fatalf("error on TestConfigurationTOML: Expected Other to be filled")
 	}
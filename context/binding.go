@@ -0,0 +1,282 @@
+package context
+
+import (
+	"encoding"
+	"fmt"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BindError is returned by the `ReadQuery`, `ReadHeaders` and `ReadParams`
+// when a value can't be bound to a struct field, it names the field and the request's key.
+type BindError struct {
+	// Source is the part of the request that the value was read from,
+	// "query", "header" or "param".
+	Source string
+	// Field is the struct field's path, i.e "Page" or "Filter.Since".
+	Field string
+	// Key is the name of the query parameter, header or path parameter.
+	Key string
+	// Value is the invalid value.
+	Value string
+	// Err is the conversion's error.
+	Err error
+}
+
+// Error implements the error interface,
+// i.e: query "page" (field Page): invalid value "first": strconv.ParseInt: parsing "first": invalid syntax.
+func (e *BindError) Error() string {
+	return fmt.Sprintf("%s %q (field %s): invalid value %q: %v", e.Source, e.Key, e.Field, e.Value, e.Err)
+}
+
+const (
+	// the struct tags of the keys, a "-" skips the field.
+	queryTagKey  = "url"
+	headerTagKey = "header"
+	paramTagKey  = "param"
+	// defaultTagKey is the struct tag of the value that is used when the key is missing,
+	// the elements of a slice are separated by commas.
+	defaultTagKey = "default"
+	// formatTagKey is the struct tag of the layout of a time.Time field, defaults to RFC3339.
+	formatTagKey = "format"
+)
+
+// ReadQuery binds the URL query parameters to the struct that "ptr" points to,
+// the parameters' names are taken from the fields' "url" tags, or the fields' names if missing.
+//
+// See `ReadHeaders` for the supported field types and tags.
+func (ctx *context) ReadQuery(ptr interface{}) error {
+	query := ctx.request.URL.Query()
+	return bindValues(ptr, "query", queryTagKey, func(key string) []string {
+		return query[key]
+	})
+}
+
+// ReadHeaders binds the request headers to the struct that "ptr" points to,
+// the headers' names are taken from the fields' "header" tags, or the fields' names if missing.
+//
+// The fields can be strings, booleans, numbers, `time.Duration`, `time.Time` (RFC3339 or the "format" tag's layout),
+// `encoding.TextUnmarshaler` implementations, pointers to them and slices of them for the repeated keys.
+// The "default" tag sets the value of a missing key, the elements of a slice are separated by commas.
+// Nested structs are bound by their fields.
+//
+// If a value can't be converted then it returns a `*BindError` that names the field, i.e:
+//
+//	type listOptions struct {
+//		Page    int           `url:"page" default:"1"`
+//		Tags    []string      `url:"tag"`
+//		Since   *time.Time    `url:"since" format:"2006-01-02"`
+//		Timeout time.Duration `header:"X-Timeout" default:"5s"`
+//	}
+func (ctx *context) ReadHeaders(ptr interface{}) error {
+	header := ctx.request.Header
+	return bindValues(ptr, "header", headerTagKey, func(key string) []string {
+		return header[textproto.CanonicalMIMEHeaderKey(key)]
+	})
+}
+
+// ReadParams binds the route's path parameters to the struct that "ptr" points to,
+// the parameters' names are taken from the fields' "param" tags, or the fields' names if missing.
+//
+// See `ReadHeaders` for the supported field types and tags.
+func (ctx *context) ReadParams(ptr interface{}) error {
+	return bindValues(ptr, "param", paramTagKey, func(key string) []string {
+		if value, ok := ctx.params.lookup(key); ok {
+			return []string{value}
+		}
+		return nil
+	})
+}
+
+// bindValues binds the values of the "lookup" to the fields of the struct that "ptr" points to.
+func bindValues(ptr interface{}, source, tagKey string, lookup func(key string) []string) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%s: expected a pointer to a struct but got %T", source, ptr)
+	}
+
+	_, err := bindStruct(v.Elem(), "", source, tagKey, lookup)
+	return err
+}
+
+// bindStruct binds the values to the fields of the "v" struct,
+// it reports whether at least one of them was set by the request, not by a default value.
+func bindStruct(v reflect.Value, prefix, source, tagKey string, lookup func(key string) []string) (bound bool, err error) {
+	typ := v.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported.
+			continue
+		}
+
+		key := f.Tag.Get(tagKey)
+		if key == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		if key == "" && isNestedStruct(f.Type) {
+			nested := field
+			if f.Type.Kind() == reflect.Ptr {
+				// allocated only if at least one of its fields is sent.
+				nested = reflect.New(f.Type.Elem()).Elem()
+			}
+
+			ok, err := bindStruct(nested, prefix+f.Name+".", source, tagKey, lookup)
+			if err != nil {
+				return bound, err
+			}
+
+			if ok && f.Type.Kind() == reflect.Ptr {
+				if !field.CanSet() {
+					continue
+				}
+				field.Set(nested.Addr())
+			}
+			bound = bound || ok
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if key == "" {
+			key = f.Name
+		}
+
+		values := lookup(key)
+		found := len(values) > 0
+		if !found {
+			def, ok := f.Tag.Lookup(defaultTagKey)
+			if !ok {
+				continue
+			}
+			values = []string{def}
+			if f.Type.Kind() == reflect.Slice {
+				values = strings.Split(def, ",")
+			}
+		}
+
+		if value, err := setFieldValue(field, values, f.Tag.Get(formatTagKey)); err != nil {
+			return bound, &BindError{Source: source, Field: prefix + f.Name, Key: key, Value: value, Err: err}
+		}
+		bound = bound || found
+	}
+
+	return bound, nil
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isNestedStruct reports whether the "typ" is a struct, or a pointer to a struct,
+// that its fields should be bound separately.
+func isNestedStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() == reflect.Struct && typ != timeType && !reflect.PtrTo(typ).Implements(textUnmarshalerType)
+}
+
+// setFieldValue sets the "values" to the "field", it returns the invalid value on failure.
+func setFieldValue(field reflect.Value, values []string, format string) (string, error) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		return setFieldValue(field.Elem(), values, format)
+	}
+
+	if field.Kind() == reflect.Slice && !isTextField(field) {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if _, err := setFieldValue(slice.Index(i), []string{value}, format); err != nil {
+				return value, err
+			}
+		}
+		field.Set(slice)
+		return "", nil
+	}
+
+	value := values[0]
+	return value, setValue(field, value, format)
+}
+
+// isTextField reports whether the "field" is set by its text form as a whole, even if it's a slice,
+// i.e a []byte or a net.IP.
+func isTextField(field reflect.Value) bool {
+	return field.Type().Elem().Kind() == reflect.Uint8 || reflect.PtrTo(field.Type()).Implements(textUnmarshalerType)
+}
+
+func setValue(field reflect.Value, value, format string) error {
+	// the time.Time is a TextUnmarshaler too but the "format" has priority.
+	switch field.Type() {
+	case timeType:
+		if format == "" {
+			format = time.RFC3339
+		}
+		t, err := time.Parse(format, value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	if field.CanAddr() {
+		if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(value))
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice: // []byte
+		field.SetBytes([]byte(value))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
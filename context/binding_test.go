@@ -0,0 +1,157 @@
+// black-box testing
+package context_test
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("invalid level")
+	}
+	return nil
+}
+
+type filter struct {
+	Since *time.Time `url:"since" format:"2006-01-02"`
+	Level level      `url:"level" default:"low"`
+}
+
+type listOptions struct {
+	Page    int      `url:"page" default:"1"`
+	Tags    []string `url:"tag"`
+	IDs     []uint64 `url:"id" default:"1,2"`
+	Sort    *string  `url:"sort"`
+	Ignored string   `url:"-"`
+	Filter  filter
+	Extra   *filter
+}
+
+type requestHeaders struct {
+	RequestID string        `header:"x-request-id"`
+	Timeout   time.Duration `header:"X-Timeout" default:"5s"`
+	ClientIP  net.IP        `header:"X-Client-IP"`
+	Accept    []string
+}
+
+type userParams struct {
+	ID      int64     `param:"id"`
+	Created time.Time `param:"created"`
+}
+
+func TestReadQuery(t *testing.T) {
+	app := iris.New()
+	app.Get("/", func(ctx context.Context) {
+		var opts listOptions
+		if err := ctx.ReadQuery(&opts); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			ctx.WriteString(err.Error())
+			return
+		}
+
+		since := ""
+		if opts.Filter.Since != nil {
+			since = opts.Filter.Since.Format("Jan 2 2006")
+		}
+		sort := "<nil>"
+		if opts.Sort != nil {
+			sort = *opts.Sort
+		}
+		ctx.Writef("%d %v %v %s %s %d %v %s", opts.Page, opts.Tags, opts.IDs, sort, since, opts.Filter.Level, opts.Extra == nil, opts.Ignored)
+	})
+
+	e := httptest.New(t, app)
+	e.GET("/").Expect().Status(httptest.StatusOK).
+		Body().Equal("1 [] [1 2] <nil>  1 true ")
+	e.GET("/").WithQueryString("page=3&tag=go&tag=web&id=7&sort=name&since=2017-10-18&level=high&Ignored=x").Expect().
+		Status(httptest.StatusOK).Body().Equal("3 [go web] [7] name Oct 18 2017 2 false ")
+
+	e.GET("/").WithQueryString("page=first").Expect().Status(httptest.StatusBadRequest).
+		Body().Equal(`query "page" (field Page): invalid value "first": strconv.ParseInt: parsing "first": invalid syntax`)
+	e.GET("/").WithQueryString("id=1&id=-2").Expect().Status(httptest.StatusBadRequest).
+		Body().Contains(`query "id" (field IDs): invalid value "-2"`)
+	e.GET("/").WithQueryString("since=18-10-2017").Expect().Status(httptest.StatusBadRequest).
+		Body().Contains(`query "since" (field Filter.Since): invalid value "18-10-2017"`)
+	e.GET("/").WithQueryString("level=medium").Expect().Status(httptest.StatusBadRequest).
+		Body().Equal(`query "level" (field Filter.Level): invalid value "medium": invalid level`)
+}
+
+func TestReadHeadersAndParams(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/{id:long}/{created}", func(ctx context.Context) {
+		var (
+			headers requestHeaders
+			params  userParams
+		)
+
+		if err := ctx.ReadHeaders(&headers); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			ctx.WriteString(err.Error())
+			return
+		}
+
+		if err := ctx.ReadParams(&params); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			ctx.WriteString(err.Error())
+			return
+		}
+
+		ctx.Writef("%s %s %s %v %d %d", headers.RequestID, headers.Timeout, headers.ClientIP, headers.Accept, params.ID, params.Created.Year())
+	})
+
+	e := httptest.New(t, app)
+	e.GET("/users/42/2017-10-18T10:00:00Z").
+		WithHeader("X-Request-Id", "abc").WithHeader("X-Client-IP", "10.0.0.1").
+		WithHeader("Accept", "text/plain").Expect().
+		Status(httptest.StatusOK).Body().Equal("abc 5s 10.0.0.1 [text/plain] 42 2017")
+
+	e.GET("/users/42/2017-10-18T10:00:00Z").WithHeader("X-Timeout", "5").Expect().
+		Status(httptest.StatusBadRequest).Body().Contains(`header "X-Timeout" (field Timeout): invalid value "5"`)
+	e.GET("/users/42/yesterday").Expect().
+		Status(httptest.StatusBadRequest).Body().Contains(`param "created" (field Created): invalid value "yesterday"`)
+}
+
+func TestReadQueryError(t *testing.T) {
+	app := iris.New()
+	var err error
+	app.Get("/", func(ctx context.Context) {
+		var opts listOptions
+		err = ctx.ReadQuery(&opts)
+	})
+
+	app.Get("/not-pointer", func(ctx context.Context) {
+		err = ctx.ReadQuery(listOptions{})
+	})
+
+	e := httptest.New(t, app)
+	e.GET("/").WithQueryString("page=first").Expect().Status(httptest.StatusOK)
+
+	bindErr, ok := err.(*context.BindError)
+	if !ok {
+		t.Fatalf("expected a *context.BindError but got %T", err)
+	}
+	if bindErr.Source != "query" || bindErr.Field != "Page" || bindErr.Key != "page" || bindErr.Value != "first" {
+		t.Fatalf("unexpected error: %#v", bindErr)
+	}
+
+	e.GET("/not-pointer").Expect().Status(httptest.StatusOK)
+	if err == nil || !strings.Contains(err.Error(), "expected a pointer to a struct") {
+		t.Fatalf("expected an error for a non-pointer value but got: %v", err)
+	}
+}
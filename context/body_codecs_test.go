@@ -0,0 +1,172 @@
+// black-box testing
+package context_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	stdhttptest "net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+type config struct {
+	Addr    string   `yaml:"Addr"`
+	Plugins []string `yaml:"Plugins"`
+}
+
+// point is a MessagePack fixarray of two positive fixints, as the tinylib/msgp generated code.
+type point struct {
+	X, Y uint8
+}
+
+func (p *point) MarshalMsg(b []byte) ([]byte, error) {
+	return append(b, 0x92, p.X, p.Y), nil
+}
+
+func (p *point) UnmarshalMsg(b []byte) ([]byte, error) {
+	if len(b) < 3 || b[0] != 0x92 || b[1] > 0x7f || b[2] > 0x7f {
+		return b, errors.New("msgpack: invalid point")
+	}
+	p.X, p.Y = b[1], b[2]
+	return b[3:], nil
+}
+
+// id is a protobuf message with a varint field number 1, as the gogo/protobuf generated code.
+type id struct {
+	Value uint8
+}
+
+func (m *id) Marshal() ([]byte, error) {
+	return []byte{0x08, m.Value}, nil
+}
+
+func (m *id) Unmarshal(data []byte) error {
+	if len(data) != 2 || data[0] != 0x08 || data[1] > 0x7f {
+		return errors.New("protobuf: invalid id")
+	}
+	m.Value = data[1]
+	return nil
+}
+
+func TestReadWriteYAML(t *testing.T) {
+	app := iris.New()
+	app.Configure(iris.WithoutBodyConsumptionOnUnmarshal)
+	app.Post("/", func(ctx context.Context) {
+		var c config
+		if err := ctx.ReadYAML(&c); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			return
+		}
+		// the body is still there.
+		var again config
+		if err := ctx.ReadYAML(&again); err != nil || again.Addr != c.Addr {
+			ctx.StatusCode(iris.StatusBadRequest)
+			return
+		}
+
+		c.Plugins = append(c.Plugins, "gzip")
+		ctx.YAML(c, context.YAML{Prefix: "---\n"})
+	})
+
+	e := httptest.New(t, app)
+	e.POST("/").WithBytes([]byte("Addr: :8080\nPlugins:\n- cors\n")).Expect().
+		Status(httptest.StatusOK).ContentType(context.ContentYAMLHeaderValue, "UTF-8").
+		Body().Equal("---\nAddr: :8080\nPlugins:\n- cors\n- gzip\n")
+	e.POST("/").WithBytes([]byte("Addr: [")).Expect().Status(httptest.StatusBadRequest)
+}
+
+func TestReadWriteMsgPackAndProtobuf(t *testing.T) {
+	app := iris.New()
+	app.Post("/point", func(ctx context.Context) {
+		var p point
+		if err := ctx.ReadMsgPack(&p); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			ctx.WriteString(err.Error())
+			return
+		}
+		p.X, p.Y = p.Y, p.X
+		ctx.MsgPack(&p)
+	})
+	app.Post("/id", func(ctx context.Context) {
+		var m id
+		if err := ctx.ReadProtobuf(&m); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			ctx.WriteString(err.Error())
+			return
+		}
+		m.Value++
+		ctx.Protobuf(&m)
+	})
+	app.Post("/config", func(ctx context.Context) {
+		var c config
+		if err := ctx.ReadProtobuf(&c); err != nil {
+			ctx.StatusCode(iris.StatusBadRequest)
+			ctx.WriteString(err.Error())
+		}
+	})
+
+	e := httptest.New(t, app)
+	e.POST("/point").WithBytes([]byte{0x92, 1, 2}).Expect().
+		Status(httptest.StatusOK).ContentType(context.ContentMsgPackHeaderValue, "").
+		Body().Equal("\x92\x02\x01")
+	e.POST("/point").WithBytes([]byte{0x91, 1}).Expect().
+		Status(httptest.StatusBadRequest).Body().Equal("msgpack: invalid point")
+	e.POST("/id").WithBytes([]byte{0x08, 41}).Expect().
+		Status(httptest.StatusOK).ContentType(context.ContentProtobufHeaderValue, "").
+		Body().Equal("\x08\x2a")
+	e.POST("/config").WithBytes([]byte{0x08, 41}).Expect().
+		Status(httptest.StatusBadRequest).Body().Contains("protobuf: *context_test.config is not a ProtobufMarshaler")
+
+	// a different library through the default options.
+	defer func(options context.Protobuf) { context.DefaultProtobufOptions = options }(context.DefaultProtobufOptions)
+	context.DefaultProtobufOptions = context.Protobuf{
+		Unmarshal: func(data []byte, message interface{}) error {
+			message.(*config).Addr = string(data)
+			return nil
+		},
+	}
+	e.POST("/config").WithBytes([]byte(":8080")).Expect().Status(httptest.StatusOK)
+}
+
+func TestWriteGzip(t *testing.T) {
+	app := iris.New()
+	app.Get("/", func(ctx context.Context) {
+		ctx.Gzip(true)
+		ctx.Protobuf(&id{Value: 42})
+	})
+
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := stdhttptest.NewRecorder()
+	req := stdhttptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	app.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected a gzip response but got %q", got)
+	}
+
+	r, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(body, []byte{0x08, 42}) {
+		t.Fatalf("expected the protobuf message but got %q", body)
+	}
+	if ct := rec.Header().Get("Content-Type"); strings.Contains(ct, "charset") {
+		t.Fatalf("expected a binary content type but got %q", ct)
+	}
+}
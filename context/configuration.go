@@ -28,8 +28,28 @@ type ConfigurationReadOnly interface {
 	// the application has performance optimizations enabled.
 	GetEnableOptimizations() bool
 
+	// GetEnableRouteWarningsAsErrors returns the configuration.EnableRouteWarningsAsErrors,
+	// if true then the routes' diagnostics warnings are failing the `Build`.
+	GetEnableRouteWarningsAsErrors() bool
+
 	// GetFireMethodNotAllowed returns the configuration.FireMethodNotAllowed.
 	GetFireMethodNotAllowed() bool
+	// GetEnableAutoHead returns the configuration.EnableAutoHead,
+	// if true then the HEAD requests are served by the GET routes.
+	GetEnableAutoHead() bool
+	// GetEnableAutoOptions returns the configuration.EnableAutoOptions,
+	// if true then the OPTIONS requests are answered with an "Allow" header.
+	GetEnableAutoOptions() bool
+	// GetEnableCaseInsensitivePaths returns the configuration.EnableCaseInsensitivePaths,
+	// if true then the not registered request paths are matched case-insensitively.
+	GetEnableCaseInsensitivePaths() bool
+	// GetEnablePathNormalization returns the configuration.EnablePathNormalization,
+	// if true then the not registered request paths are matched after their NFC normalization.
+	GetEnablePathNormalization() bool
+	// GetEnableCanonicalPathRedirect returns the configuration.EnableCanonicalPathRedirect,
+	// if true then the requests are redirected to the route's registered path
+	// when they're matched case-insensitively or after their normalization.
+	GetEnableCanonicalPathRedirect() bool
 	// GetDisableBodyConsumptionOnUnmarshal returns the configuration.GetDisableBodyConsumptionOnUnmarshal,
 	// manages the reading behavior of the context's body readers/binders.
 	// If returns true then the body consumption by the `context.UnmarshalBody/ReadJSON/ReadXML`
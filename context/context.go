@@ -26,6 +26,7 @@ import (
 	"github.com/json-iterator/go"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday"
+	"gopkg.in/yaml.v2"
 
 	"github.com/kataras/iris/core/errors"
 	"github.com/kataras/iris/core/memstore"
@@ -74,37 +75,81 @@ func (u UnmarshalerFunc) Unmarshal(data []byte, v interface{}) error {
 // context's request dynamic path params are being kept.
 // Empty if the route is static.
 type RequestParams struct {
-	store memstore.Store
+	// the path parameters by order, the storage is reused between requests,
+	// so setting the parameters of a request does not allocate.
+	store []requestParam
+	// the converted values of the path parameters
+	// which their param type has a converter, i.e {id:uuid}.
+	values memstore.Store
+}
+
+type requestParam struct {
+	key   string
+	value string
 }
 
 // Set adds a key-value pair to the path parameters values
 // it's being called internally so it shouldn't be used as a local storage by the user, use `ctx.Values()` instead.
 func (r *RequestParams) Set(key, value string) {
-	r.store.Set(key, value)
+	for i := range r.store {
+		if r.store[i].key == key {
+			r.store[i].value = value
+			return
+		}
+	}
+
+	r.store = append(r.store, requestParam{key: key, value: value})
+}
+
+// SetValue sets the converted value of a path parameter,
+// it's being called internally by the router for the param types that have a converter.
+func (r *RequestParams) SetValue(key string, value interface{}) {
+	r.values.Set(key, value)
 }
 
 // Visit accepts a visitor which will be filled
 // by the key-value params.
 func (r *RequestParams) Visit(visitor func(key string, value string)) {
-	r.store.Visit(func(k string, v interface{}) {
-		visitor(k, v.(string)) // always string here.
-	})
+	for _, p := range r.store {
+		visitor(p.key, p.value)
+	}
+}
+
+// lookup returns the value of a path parameter and true if it exists.
+func (r RequestParams) lookup(key string) (string, bool) {
+	for _, p := range r.store {
+		if p.key == key {
+			return p.value, true
+		}
+	}
+	return "", false
 }
 
 // GetEntry returns the internal Entry of the memstore, as value
 // if not found then it returns a zero Entry and false.
 func (r RequestParams) GetEntry(key string) (memstore.Entry, bool) {
-	// we don't return the pointer here, we don't want to give the end-developer
-	// the strength to change the entry that way.
-	if e := r.store.GetEntry(key); e != nil {
-		return *e, true
+	if v, ok := r.lookup(key); ok {
+		return memstore.Entry{Key: key, ValueRaw: v}, true
 	}
 	return memstore.Entry{}, false
 }
 
 // Get returns a path parameter's value based on its route's dynamic path key.
 func (r RequestParams) Get(key string) string {
-	return r.store.GetString(key)
+	v, _ := r.lookup(key)
+	return v
+}
+
+// GetValue returns a path parameter's typed value based on its route's dynamic path key,
+// the value is converted by the param type's converter, i.e a uuid.UUID for a {id:uuid},
+// see `app.Macros().Register` for more.
+//
+// If the param type has no converter then it returns the parameter's string value.
+func (r RequestParams) GetValue(key string) interface{} {
+	if v := r.values.Get(key); v != nil {
+		return v
+	}
+	return r.Get(key)
 }
 
 // GetTrim returns a path parameter's value without trailing spaces based on its route's dynamic path key.
@@ -125,17 +170,26 @@ func (r RequestParams) GetDecoded(key string) string {
 
 // GetInt returns the path parameter's value as int, based on its key.
 func (r RequestParams) GetInt(key string) (int, error) {
-	return r.store.GetInt(key)
+	if v := r.Get(key); v != "" {
+		return strconv.Atoi(v)
+	}
+	return 0, nil
 }
 
 // GetInt64 returns the path paramete's value as int64, based on its key.
 func (r RequestParams) GetInt64(key string) (int64, error) {
-	return r.store.GetInt64(key)
+	if v := r.Get(key); v != "" {
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, nil
 }
 
 // GetFloat64 returns a path parameter's value based as float64 on its route's dynamic path key.
 func (r RequestParams) GetFloat64(key string) (float64, error) {
-	return r.store.GetFloat64(key)
+	if v := r.Get(key); v != "" {
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, nil
 }
 
 // GetBool returns the path parameter's value as bool, based on its key.
@@ -143,7 +197,10 @@ func (r RequestParams) GetFloat64(key string) (float64, error) {
 // or "0" or "f" or "F" or "FALSE" or "false" or "False".
 // Any other value returns an error.
 func (r RequestParams) GetBool(key string) (bool, error) {
-	return r.store.GetBool(key)
+	if v, ok := r.lookup(key); ok {
+		return strconv.ParseBool(v)
+	}
+	return false, nil
 }
 
 // GetIntUnslashed same as Get but it removes the first slash if found.
@@ -167,7 +224,7 @@ func (r RequestParams) GetIntUnslashed(key string) (int, error) {
 
 // Len returns the full length of the parameters.
 func (r RequestParams) Len() int {
-	return r.store.Len()
+	return len(r.store)
 }
 
 // Context is the midle-man server's "object" for the clients.
@@ -219,9 +276,20 @@ type Context interface {
 	// from this context you should use the `Exec` function
 	// or change the handlers via `SetHandlers/AddHandler` functions.
 	SetCurrentRouteName(currentRouteName string)
+	// SetCurrentRoute sets the "read-only" route that serves this request,
+	// it's being initialized by the Router with the route that was matched
+	// and not by its name, because more than one routes may share the same name.
+	SetCurrentRoute(route RouteReadOnly)
 	// GetCurrentRoute returns the current registered "read-only" route that
 	// was being registered to this request's path.
 	GetCurrentRoute() RouteReadOnly
+	// SetVersion sets the API version that the current route was registered for
+	// and it's resolved from the request's "Accept-Version" or "Accept" headers.
+	// It's being initialized by the Router, see `Party#Version`.
+	SetVersion(version string)
+	// GetVersion returns the resolved API version of the current request,
+	// empty if the current route is not versioned.
+	GetVersion() string
 
 	// Do calls the SetHandlers(handlers)
 	// and executes the first handler,
@@ -509,6 +577,20 @@ type Context interface {
 	//
 	// same as Request.FormFile.
 	FormFile(key string) (multipart.File, *multipart.FileHeader, error)
+	// UploadFormFiles streams all of the files of the multipart request body to the "destDir" directory,
+	// each one of them is written to the disk while it's read, without keeping the whole file in memory.
+	// The directory is created if it does not exist.
+	//
+	// The file names are sanitized and they are made unique, the existing files are never replaced.
+	// The non-file form values are available through the `FormValue` and `PostValue` afterwards.
+	//
+	// The "opts" limit the size of each file, the total size, the number of the files and their sniffed MIME types
+	// and report the progress. If a limit is exceeded, or on any other failure, all of the files
+	// that were written by this call, including the partial ones, are removed and an error is returned,
+	// see the `ErrUploadTooLarge` and `ErrUploadTypeNotAllowed`.
+	//
+	// It returns the stored files.
+	UploadFormFiles(destDir string, opts UploadOptions) ([]UploadedFile, error)
 
 	//  +------------------------------------------------------------+
 	//  | Custom HTTP Errors                                         |
@@ -537,9 +619,47 @@ type Context interface {
 	ReadJSON(jsonObject interface{}) error
 	// ReadXML reads XML from request's body and binds it to a value of any xml-valid type.
 	ReadXML(xmlObject interface{}) error
+	// ReadYAML reads YAML from request's body and binds it to a value of any yaml-valid type.
+	ReadYAML(yamlObject interface{}) error
+	// ReadMsgPack reads MessagePack from request's body and binds it to the "msgpackObject",
+	// it's decoded by the `DefaultMsgPackOptions#Unmarshal` or, if missing, by the object's `UnmarshalMsg`,
+	// see `MsgPackUnmarshaler`.
+	ReadMsgPack(msgpackObject interface{}) error
+	// ReadProtobuf reads a protobuf message from request's body and binds it to the "message",
+	// it's decoded by the `DefaultProtobufOptions#Unmarshal` or, if missing, by the message's `Unmarshal`,
+	// see `ProtobufUnmarshaler`.
+	ReadProtobuf(message interface{}) error
 	// ReadForm binds the formObject  with the form data
 	// it supports any kind of struct.
 	ReadForm(formObject interface{}) error
+	// ReadBody reads the request's body, based on its "Content-Type" header, and binds it to the "ptr",
+	// JSON, XML, YAML, MessagePack, protobuf, url-encoded and multipart forms are supported.
+	// Then it validates the "ptr" based on its fields' "validate" tags, see `Validate`.
+	//
+	// It returns the `ErrUnsupportedMediaType` if the body's content type is not supported,
+	// the decoder's error if the body is not valid or the `ValidationErrors` that list each one of the
+	// failing fields, the caller can render them with a 400 Bad Request or a 422 Unprocessable Entity.
+	ReadBody(ptr interface{}) error
+	// ReadQuery binds the URL query parameters to the struct that "ptr" points to,
+	// the parameters' names are taken from the fields' "url" tags, or the fields' names if missing.
+	//
+	// See `ReadHeaders` for the supported field types and tags.
+	ReadQuery(ptr interface{}) error
+	// ReadHeaders binds the request headers to the struct that "ptr" points to,
+	// the headers' names are taken from the fields' "header" tags, or the fields' names if missing.
+	//
+	// The fields can be strings, booleans, numbers, `time.Duration`, `time.Time` (RFC3339 or the "format" tag's layout),
+	// `encoding.TextUnmarshaler` implementations, pointers to them and slices of them for the repeated keys.
+	// The "default" tag sets the value of a missing key, the elements of a slice are separated by commas.
+	// Nested structs are bound by their fields.
+	//
+	// If a value can't be converted then it returns a `*BindError` that names the field.
+	ReadHeaders(ptr interface{}) error
+	// ReadParams binds the route's path parameters to the struct that "ptr" points to,
+	// the parameters' names are taken from the fields' "param" tags, or the fields' names if missing.
+	//
+	// See `ReadHeaders` for the supported field types and tags.
+	ReadParams(ptr interface{}) error
 
 	//  +------------------------------------------------------------+
 	//  | Body (raw) Writers                                         |
@@ -672,6 +792,9 @@ type Context interface {
 	//
 	// Examples: https://github.com/kataras/iris/tree/master/_examples/view/
 	View(filename string) error
+	// NegotiateView sets the template file that the `Negotiate` renders, with the value as its binding data,
+	// when the client prefers "text/html". Layouts are respected, see `ViewLayout`.
+	NegotiateView(filename string)
 
 	// Binary writes out the raw bytes as binary data.
 	Binary(data []byte) (int, error)
@@ -685,8 +808,22 @@ type Context interface {
 	JSONP(v interface{}, options ...JSONP) (int, error)
 	// XML marshals the given interface object and writes the XML response.
 	XML(v interface{}, options ...XML) (int, error)
+	// YAML marshals the given interface object and writes the YAML response.
+	YAML(v interface{}, options ...YAML) (int, error)
+	// MsgPack marshals the given interface object and writes the MessagePack response.
+	MsgPack(v interface{}, options ...MsgPack) (int, error)
+	// Protobuf marshals the given protobuf message and writes the binary response.
+	Protobuf(message interface{}, options ...Protobuf) (int, error)
 	// Markdown parses the markdown to html and renders to client.
 	Markdown(markdownB []byte, options ...Markdown) (int, error)
+	// Negotiate writes the "v" with the renderer of the media type that the client prefers,
+	// based on the request's "Accept" header and its quality values, and the registered renderers of the
+	// `Application#Renderers`. The optional "offers" limit the media types, by order of preference,
+	// i.e `ctx.Negotiate(user, "application/json", "application/xml")`.
+	//
+	// It sets the "Vary: Accept" header, the "Content-Type" to the selected media type
+	// and if none of them is acceptable then it sets the 406 Not Acceptable status code and returns an error.
+	Negotiate(v interface{}, offers ...string) error
 
 	//  +------------------------------------------------------------+
 	//  | Serve files                                                |
@@ -861,6 +998,10 @@ type context struct {
 	request *http.Request
 	// the current route's name registered to this request path.
 	currentRouteName string
+	// the current route, if it's set by the `SetCurrentRoute`.
+	currentRoute RouteReadOnly
+	// the resolved API version of the current route, if versioned.
+	version string
 
 	// the local key-value storage
 	params RequestParams  // url named parameters
@@ -897,7 +1038,11 @@ func (ctx *context) BeginRequest(w http.ResponseWriter, r *http.Request) {
 	ctx.handlers = nil           // will be filled by router.Serve/HTTP
 	ctx.values = ctx.values[0:0] // >>      >>     by context.Values().Set
 	ctx.params.store = ctx.params.store[0:0]
+	ctx.params.values = ctx.params.values[0:0]
 	ctx.request = r
+	ctx.currentRouteName = ""
+	ctx.currentRoute = nil
+	ctx.version = ""
 	ctx.currentHandlerIndex = 0
 	ctx.writer = AcquireResponseWriter()
 	ctx.writer.BeginResponse(w)
@@ -965,14 +1110,40 @@ func (ctx *context) Request() *http.Request {
 // or change the handlers via `SetHandlers/AddHandler` functions.
 func (ctx *context) SetCurrentRouteName(currentRouteName string) {
 	ctx.currentRouteName = currentRouteName
+	ctx.currentRoute = nil
+}
+
+// SetCurrentRoute sets the "read-only" route that serves this request,
+// it's being initialized by the Router with the route that was matched
+// and not by its name, because more than one routes may share the same name.
+func (ctx *context) SetCurrentRoute(route RouteReadOnly) {
+	ctx.currentRoute = route
+	// the name is used only when the route is not set, see `GetCurrentRoute`.
+	ctx.currentRouteName = ""
 }
 
 // GetCurrentRoute returns the current registered "read-only" route that
 // was being registered to this request's path.
 func (ctx *context) GetCurrentRoute() RouteReadOnly {
+	if ctx.currentRoute != nil {
+		return ctx.currentRoute
+	}
 	return ctx.app.GetRouteReadOnly(ctx.currentRouteName)
 }
 
+// SetVersion sets the API version that the current route was registered for
+// and it's resolved from the request's "Accept-Version" or "Accept" headers.
+// It's being initialized by the Router, see `Party#Version`.
+func (ctx *context) SetVersion(version string) {
+	ctx.version = version
+}
+
+// GetVersion returns the resolved API version of the current request,
+// empty if the current route is not versioned.
+func (ctx *context) GetVersion() string {
+	return ctx.version
+}
+
 // Do calls the SetHandlers(handlers)
 // and executes the first handler,
 // handlers should not be empty.
@@ -1744,6 +1915,51 @@ func (ctx *context) ReadXML(xmlObject interface{}) error {
 	return ctx.UnmarshalBody(xmlObject, UnmarshalerFunc(xml.Unmarshal))
 }
 
+// ReadYAML reads YAML from request's body and binds it to a value of any yaml-valid type.
+func (ctx *context) ReadYAML(yamlObject interface{}) error {
+	return ctx.UnmarshalBody(yamlObject, UnmarshalerFunc(yaml.Unmarshal))
+}
+
+var (
+	errMsgPackCodecMissing  = errors.New("msgpack: %T is not a MsgPackMarshaler/MsgPackUnmarshaler and DefaultMsgPackOptions has no Marshal/Unmarshal")
+	errProtobufCodecMissing = errors.New("protobuf: %T is not a ProtobufMarshaler/ProtobufUnmarshaler and DefaultProtobufOptions has no Marshal/Unmarshal")
+)
+
+// ReadMsgPack reads MessagePack from request's body and binds it to the "msgpackObject",
+// it's decoded by the `DefaultMsgPackOptions#Unmarshal` or, if missing, by the object's `UnmarshalMsg`,
+// see `MsgPackUnmarshaler`.
+func (ctx *context) ReadMsgPack(msgpackObject interface{}) error {
+	return ctx.UnmarshalBody(msgpackObject, UnmarshalerFunc(func(data []byte, _ interface{}) error {
+		if unmarshal := DefaultMsgPackOptions.Unmarshal; unmarshal != nil {
+			return unmarshal(data, msgpackObject)
+		}
+
+		if u, ok := msgpackObject.(MsgPackUnmarshaler); ok {
+			_, err := u.UnmarshalMsg(data)
+			return err
+		}
+
+		return errMsgPackCodecMissing.Format(msgpackObject)
+	}))
+}
+
+// ReadProtobuf reads a protobuf message from request's body and binds it to the "message",
+// it's decoded by the `DefaultProtobufOptions#Unmarshal` or, if missing, by the message's `Unmarshal`,
+// see `ProtobufUnmarshaler`.
+func (ctx *context) ReadProtobuf(message interface{}) error {
+	return ctx.UnmarshalBody(message, UnmarshalerFunc(func(data []byte, _ interface{}) error {
+		if unmarshal := DefaultProtobufOptions.Unmarshal; unmarshal != nil {
+			return unmarshal(data, message)
+		}
+
+		if u, ok := message.(ProtobufUnmarshaler); ok {
+			return u.Unmarshal(data)
+		}
+
+		return errProtobufCodecMissing.Format(message)
+	}))
+}
+
 var (
 	errReadBody = errors.New("while trying to read %s from the request body. Trace %s")
 )
@@ -1762,6 +1978,53 @@ func (ctx *context) ReadForm(formObject interface{}) error {
 	return errReadBody.With(formbinder.Decode(values, formObject))
 }
 
+// ErrUnsupportedMediaType is returned by the `ReadBody` when the request body's content type is not supported,
+// check it with `ErrUnsupportedMediaType.Equal(err)`.
+var ErrUnsupportedMediaType = errors.New("unsupported media type %q")
+
+// ReadBody reads the request's body, based on its "Content-Type" header, and binds it to the "ptr",
+// JSON, XML, YAML, MessagePack, protobuf, url-encoded and multipart forms are supported.
+// Then it validates the "ptr" based on its fields' "validate" tags, see `Validate`.
+//
+// It returns the `ErrUnsupportedMediaType` if the body's content type is not supported,
+// the decoder's error if the body is not valid or the `ValidationErrors` that list each one of the
+// failing fields, the caller can render them with a 400 Bad Request or a 422 Unprocessable Entity.
+func (ctx *context) ReadBody(ptr interface{}) error {
+	contentType := ctx.GetHeader(contentTypeHeaderKey)
+	if idx := strings.IndexByte(contentType, ';'); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+
+	var err error
+	switch {
+	case contentType == ContentJSONHeaderValue || strings.HasSuffix(contentType, "+json"):
+		err = ctx.ReadJSON(ptr)
+	case contentType == ContentXMLHeaderValue || contentType == "application/xml" || strings.HasSuffix(contentType, "+xml"):
+		err = ctx.ReadXML(ptr)
+	case contentType == ContentYAMLHeaderValue || contentType == "application/yaml" || contentType == "text/yaml":
+		err = ctx.ReadYAML(ptr)
+	case contentType == ContentMsgPackHeaderValue || contentType == "application/x-msgpack":
+		err = ctx.ReadMsgPack(ptr)
+	case contentType == ContentProtobufHeaderValue || contentType == "application/protobuf":
+		err = ctx.ReadProtobuf(ptr)
+	case contentType == "application/x-www-form-urlencoded":
+		err = ctx.ReadForm(ptr)
+	case contentType == "multipart/form-data":
+		if err = ctx.request.ParseMultipartForm(DefaultMaxMemory); err == nil {
+			err = errReadBody.With(formbinder.Decode(ctx.request.Form, ptr))
+		}
+	default:
+		return ErrUnsupportedMediaType.Format(contentType)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return Validate(ptr)
+}
+
 //  +------------------------------------------------------------+
 //  | Body (raw) Writers                                         |
 //  +------------------------------------------------------------+
@@ -2093,6 +2356,12 @@ const (
 
 	// ContentMarkdownHeaderValue custom key/content type, the real is the text/html.
 	ContentMarkdownHeaderValue = "text/markdown"
+	// ContentYAMLHeaderValue header value for YAML data.
+	ContentYAMLHeaderValue = "application/x-yaml"
+	// ContentMsgPackHeaderValue header value for MessagePack data.
+	ContentMsgPackHeaderValue = "application/msgpack"
+	// ContentProtobufHeaderValue header value for protobuf messages.
+	ContentProtobufHeaderValue = "application/x-protobuf"
 )
 
 // Binary writes out the raw bytes as binary data.
@@ -2143,6 +2412,60 @@ type Markdown struct {
 	Sanitize bool
 }
 
+// YAML contains the options for the YAML (Context's) Renderer.
+type YAML struct {
+	// content-specific
+	Prefix string
+}
+
+// MsgPack contains the options for the MessagePack (Context's) Renderer and Reader.
+//
+// Iris has no MessagePack dependency, the values are encoded by their `MarshalMsg` and decoded
+// by their `UnmarshalMsg`, i.e the generated code of the github.com/tinylib/msgp,
+// set the `Marshal` and `Unmarshal` to use a different library, i.e:
+//
+//	context.DefaultMsgPackOptions = context.MsgPack{Marshal: msgpack.Marshal, Unmarshal: msgpack.Unmarshal}
+type MsgPack struct {
+	// content-specific
+	Marshal   func(v interface{}) ([]byte, error)
+	Unmarshal func(data []byte, v interface{}) error
+}
+
+// Protobuf contains the options for the protobuf (Context's) Renderer and Reader.
+//
+// Iris has no protobuf dependency, the messages are encoded by their `Marshal` and decoded
+// by their `Unmarshal`, i.e the generated code of the github.com/gogo/protobuf,
+// set the `Marshal` and `Unmarshal` to use a different library, i.e the github.com/golang/protobuf/proto.
+type Protobuf struct {
+	// content-specific
+	Marshal   func(message interface{}) ([]byte, error)
+	Unmarshal func(data []byte, message interface{}) error
+}
+
+// MsgPackMarshaler is implemented by the values that can encode themselves to MessagePack,
+// i.e the generated code of the github.com/tinylib/msgp.
+type MsgPackMarshaler interface {
+	MarshalMsg(b []byte) ([]byte, error)
+}
+
+// MsgPackUnmarshaler is implemented by the values that can decode themselves from MessagePack,
+// i.e the generated code of the github.com/tinylib/msgp.
+type MsgPackUnmarshaler interface {
+	UnmarshalMsg(b []byte) ([]byte, error)
+}
+
+// ProtobufMarshaler is implemented by the protobuf messages that can encode themselves,
+// i.e the generated code of the github.com/gogo/protobuf.
+type ProtobufMarshaler interface {
+	Marshal() ([]byte, error)
+}
+
+// ProtobufUnmarshaler is implemented by the protobuf messages that can decode themselves,
+// i.e the generated code of the github.com/gogo/protobuf.
+type ProtobufUnmarshaler interface {
+	Unmarshal(data []byte) error
+}
+
 var (
 	newLineB = []byte("\n")
 	// the html codes for unescaping
@@ -2385,6 +2708,133 @@ func (ctx *context) Markdown(markdownB []byte, opts ...Markdown) (int, error) {
 	return n, err
 }
 
+// WriteYAML marshals the given interface object and writes the YAML response to the writer.
+func WriteYAML(writer io.Writer, v interface{}, options YAML) (int, error) {
+	result, err := yaml.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	if prefix := options.Prefix; prefix != "" {
+		result = append([]byte(prefix), result...)
+	}
+
+	return writer.Write(result)
+}
+
+// DefaultYAMLOptions is the optional settings that are being used
+// from `ctx.YAML`.
+var DefaultYAMLOptions = YAML{}
+
+// YAML marshals the given interface object and writes the YAML response to the client.
+func (ctx *context) YAML(v interface{}, opts ...YAML) (int, error) {
+	options := DefaultYAMLOptions
+
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	ctx.ContentType(ContentYAMLHeaderValue)
+
+	n, err := WriteYAML(ctx.writer, v, options)
+	if err != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		return 0, err
+	}
+
+	return n, err
+}
+
+// WriteMsgPack marshals the given interface object and writes the MessagePack response to the writer.
+func WriteMsgPack(writer io.Writer, v interface{}, options MsgPack) (int, error) {
+	var (
+		result []byte
+		err    error
+	)
+
+	if marshal := options.Marshal; marshal != nil {
+		result, err = marshal(v)
+	} else if m, ok := v.(MsgPackMarshaler); ok {
+		result, err = m.MarshalMsg(nil)
+	} else {
+		err = errMsgPackCodecMissing.Format(v)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return writer.Write(result)
+}
+
+// DefaultMsgPackOptions is the optional settings that are being used
+// from `ctx.MsgPack` and `ctx.ReadMsgPack`.
+var DefaultMsgPackOptions = MsgPack{}
+
+// MsgPack marshals the given interface object and writes the MessagePack response to the client.
+func (ctx *context) MsgPack(v interface{}, opts ...MsgPack) (int, error) {
+	options := DefaultMsgPackOptions
+
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	ctx.writer.Header().Set(contentTypeHeaderKey, ContentMsgPackHeaderValue)
+
+	n, err := WriteMsgPack(ctx.writer, v, options)
+	if err != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		return 0, err
+	}
+
+	return n, err
+}
+
+// WriteProtobuf marshals the given protobuf message and writes the binary response to the writer.
+func WriteProtobuf(writer io.Writer, message interface{}, options Protobuf) (int, error) {
+	var (
+		result []byte
+		err    error
+	)
+
+	if marshal := options.Marshal; marshal != nil {
+		result, err = marshal(message)
+	} else if m, ok := message.(ProtobufMarshaler); ok {
+		result, err = m.Marshal()
+	} else {
+		err = errProtobufCodecMissing.Format(message)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return writer.Write(result)
+}
+
+// DefaultProtobufOptions is the optional settings that are being used
+// from `ctx.Protobuf` and `ctx.ReadProtobuf`.
+var DefaultProtobufOptions = Protobuf{}
+
+// Protobuf marshals the given protobuf message and writes the binary response to the client.
+func (ctx *context) Protobuf(message interface{}, opts ...Protobuf) (int, error) {
+	options := DefaultProtobufOptions
+
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	ctx.writer.Header().Set(contentTypeHeaderKey, ContentProtobufHeaderValue)
+
+	n, err := WriteProtobuf(ctx.writer, message, options)
+	if err != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		return 0, err
+	}
+
+	return n, err
+}
+
 //  +------------------------------------------------------------+
 //  | Serve files                                                |
 //  +------------------------------------------------------------+
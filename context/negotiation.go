@@ -0,0 +1,404 @@
+package context
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// Renderer writes a value to the client, it's used by the `Context#Negotiate`
+// after the response's content type is set to the negotiated media type.
+type Renderer interface {
+	Render(ctx Context, v interface{}) error
+}
+
+// RendererFunc is the function form of a `Renderer`.
+type RendererFunc func(ctx Context, v interface{}) error
+
+// Render calls the "r" function.
+func (r RendererFunc) Render(ctx Context, v interface{}) error {
+	return r(ctx, v)
+}
+
+// ConditionalRenderer is an optional interface of a `Renderer`,
+// its `CanRender` reports whether it can write the "v",
+// if not then the `Context#Negotiate` selects the next acceptable renderer,
+// i.e the protobuf renderer can write only protobuf messages.
+type ConditionalRenderer interface {
+	Renderer
+	CanRender(ctx Context, v interface{}) bool
+}
+
+type conditionalRenderer struct {
+	Renderer
+	canRender func(ctx Context, v interface{}) bool
+}
+
+func (r conditionalRenderer) CanRender(ctx Context, v interface{}) bool {
+	return r.canRender(ctx, v)
+}
+
+// Renderers is the registry of the renderers by their media types,
+// the `Context#Negotiate` selects one of them based on the request's "Accept" header.
+//
+// Each Application has its own Renderers, see `Application#Renderers`.
+type Renderers struct {
+	mu sync.RWMutex
+	// the media types by registration order, they are the server's preference
+	// when the client accepts more than one of them with the same quality.
+	mediaTypes []string
+	renderers  map[string]Renderer
+}
+
+// NewRenderers returns a new Renderers registry with the default renderers,
+// by order of preference:
+// "application/json", "application/xml", "text/xml", "application/x-yaml", "application/yaml",
+// "application/msgpack", "application/x-msgpack", "application/x-protobuf", "application/protobuf",
+// "text/plain" and "text/html".
+//
+// The MessagePack and protobuf renderers can write the values that can marshal themselves,
+// i.e the generated code of the tinylib/msgp and the gogo/protobuf,
+// see the `MsgPackMarshaler` and the `ProtobufMarshaler`,
+// or through the `DefaultMsgPackOptions` and `DefaultProtobufOptions`.
+//
+// The "text/html" renderer renders the template file that is set by the `Context#NegotiateView`.
+func NewRenderers() *Renderers {
+	r := &Renderers{renderers: make(map[string]Renderer)}
+
+	r.Register(ContentJSONHeaderValue, RendererFunc(renderJSON))
+	r.Register("application/xml", RendererFunc(renderXML))
+	r.Register(ContentXMLHeaderValue, RendererFunc(renderXML))
+	r.Register(ContentYAMLHeaderValue, RendererFunc(renderYAML))
+	r.Register("application/yaml", RendererFunc(renderYAML))
+	msgpack := conditionalRenderer{RendererFunc(renderMsgPack), canRenderMsgPack}
+	r.Register(ContentMsgPackHeaderValue, msgpack)
+	r.Register("application/x-msgpack", msgpack)
+	protobuf := conditionalRenderer{RendererFunc(renderProtobuf), canRenderProtobuf}
+	r.Register(ContentProtobufHeaderValue, protobuf)
+	r.Register("application/protobuf", protobuf)
+	r.Register(ContentTextHeaderValue, conditionalRenderer{RendererFunc(renderText), canRenderText})
+	r.Register(ContentHTMLHeaderValue, conditionalRenderer{RendererFunc(renderView), canRenderView})
+
+	return r
+}
+
+// Register registers a "renderer" for a "mediaType", i.e "application/vnd.api+json",
+// if the media type is already registered then its renderer is replaced
+// and it keeps its order of preference, otherwise it's the last one.
+func (r *Renderers) Register(mediaType string, renderer Renderer) {
+	mediaType = strings.ToLower(mediaType)
+
+	r.mu.Lock()
+	if _, ok := r.renderers[mediaType]; !ok {
+		r.mediaTypes = append(r.mediaTypes, mediaType)
+	}
+	r.renderers[mediaType] = renderer
+	r.mu.Unlock()
+}
+
+// Remove removes the renderer of a "mediaType".
+func (r *Renderers) Remove(mediaType string) {
+	mediaType = strings.ToLower(mediaType)
+
+	r.mu.Lock()
+	if _, ok := r.renderers[mediaType]; ok {
+		delete(r.renderers, mediaType)
+		for i, m := range r.mediaTypes {
+			if m == mediaType {
+				r.mediaTypes = append(r.mediaTypes[:i], r.mediaTypes[i+1:]...)
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+}
+
+// Get returns the renderer of a "mediaType", otherwise nil.
+func (r *Renderers) Get(mediaType string) Renderer {
+	r.mu.RLock()
+	renderer := r.renderers[strings.ToLower(mediaType)]
+	r.mu.RUnlock()
+	return renderer
+}
+
+// MediaTypes returns the registered media types by order of preference.
+func (r *Renderers) MediaTypes() []string {
+	r.mu.RLock()
+	mediaTypes := append([]string(nil), r.mediaTypes...)
+	r.mu.RUnlock()
+	return mediaTypes
+}
+
+// Select returns the media type and the renderer that the client prefers to receive the "v" with,
+// based on the "accept" header's value. The "offers" can limit the media types, by order of preference,
+// if empty then all of the registered are offered.
+// It returns an empty media type if none of them is acceptable.
+func (r *Renderers) Select(ctx Context, accept string, v interface{}, offers ...string) (string, Renderer) {
+	if len(offers) == 0 {
+		offers = r.MediaTypes()
+	}
+
+	accepted := ParseAccept(accept)
+
+	var (
+		selected string
+		renderer Renderer
+		best     float64
+	)
+
+	for _, mediaType := range offers {
+		mediaType = strings.ToLower(mediaType)
+		rr := r.Get(mediaType)
+		if rr == nil {
+			continue
+		}
+
+		q := MediaTypeQuality(accepted, mediaType)
+		if q <= best {
+			continue
+		}
+
+		if c, ok := rr.(ConditionalRenderer); ok && !c.CanRender(ctx, v) {
+			continue
+		}
+
+		selected, renderer, best = mediaType, rr, q
+	}
+
+	return selected, renderer
+}
+
+// AcceptedMediaType is a media range of the "Accept" header, i.e "text/*;q=0.8".
+type AcceptedMediaType struct {
+	MediaType string
+	Q         float64
+}
+
+// Specificity returns 0 for "*/*", 1 for "type/*" and 2 for "type/subtype".
+func (a AcceptedMediaType) Specificity() int {
+	if a.MediaType == "*/*" {
+		return 0
+	}
+	if strings.HasSuffix(a.MediaType, "/*") {
+		return 1
+	}
+	return 2
+}
+
+// ParseAccept parses the "Accept" header's value, it returns nil if it's empty.
+func ParseAccept(header string) (accepted []AcceptedMediaType) {
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		a := AcceptedMediaType{MediaType: part, Q: 1}
+		if idx := strings.IndexByte(part, ';'); idx != -1 {
+			a.MediaType = strings.TrimSpace(part[:idx])
+			for _, param := range strings.Split(part[idx+1:], ";") {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						a.Q = q
+					}
+				}
+			}
+		}
+
+		a.MediaType = strings.ToLower(a.MediaType)
+		accepted = append(accepted, a)
+	}
+
+	return
+}
+
+// MediaTypeQuality returns the quality, from 0 to 1, that the "mediaType" is acceptable by the client,
+// it's the quality of the most specific of the "accepted" media ranges that matches the media type.
+// If the client doesn't send any media ranges then every media type is acceptable.
+func MediaTypeQuality(accepted []AcceptedMediaType, mediaType string) float64 {
+	if len(accepted) == 0 {
+		return 1
+	}
+
+	q, specificity := 0.0, -1
+	for _, a := range accepted {
+		if s := a.Specificity(); s > specificity && MediaTypeMatches(a.MediaType, mediaType) {
+			q, specificity = a.Q, s
+		}
+	}
+
+	return q
+}
+
+// MediaTypeMatches reports whether the "mediaType" matches the "pattern",
+// the pattern can be a media range, i.e "*/*" or "image/*".
+func MediaTypeMatches(pattern, mediaType string) bool {
+	if pattern == "*/*" || pattern == mediaType {
+		return true
+	}
+
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mediaType, pattern[:len(pattern)-1])
+	}
+
+	if strings.HasSuffix(mediaType, "/*") { // i.e Produces "text/*".
+		return strings.HasPrefix(pattern, mediaType[:len(mediaType)-1])
+	}
+
+	return false
+}
+
+var errNotAcceptable = errors.New("none of the %s media types is acceptable by %q")
+
+// Negotiate writes the "v" with the renderer of the media type that the client prefers,
+// based on the request's "Accept" header and its quality values, and the registered renderers of the
+// `Application#Renderers`. The optional "offers" limit the media types, by order of preference,
+// i.e `ctx.Negotiate(user, "application/json", "application/xml")`.
+//
+// It sets the "Vary: Accept" header, the "Content-Type" to the selected media type
+// and if none of them is acceptable then it sets the 406 Not Acceptable status code and returns an error.
+func (ctx *context) Negotiate(v interface{}, offers ...string) error {
+	ctx.writer.Header().Add(varyHeaderKey, acceptHeaderKey)
+
+	accept := ctx.GetHeader(acceptHeaderKey)
+	mediaType, renderer := ctx.Application().Renderers().Select(ctx, accept, v, offers...)
+	if renderer == nil {
+		ctx.StatusCode(http.StatusNotAcceptable)
+		mediaTypes := offers
+		if len(mediaTypes) == 0 {
+			mediaTypes = ctx.Application().Renderers().MediaTypes()
+		}
+		return errNotAcceptable.Format(strings.Join(mediaTypes, ", "), accept)
+	}
+
+	if isTextualMediaType(mediaType) {
+		ctx.ContentType(mediaType)
+	} else {
+		ctx.writer.Header().Set(contentTypeHeaderKey, mediaType)
+	}
+
+	if err := renderer.Render(ctx, v); err != nil {
+		ctx.StatusCode(http.StatusInternalServerError)
+		return err
+	}
+
+	return nil
+}
+
+// NegotiateView sets the template file that the `Negotiate` renders, with the value as its binding data,
+// when the client prefers "text/html". Layouts are respected, see `ViewLayout`.
+func (ctx *context) NegotiateView(filename string) {
+	ctx.values.Set(negotiateViewContextKey, filename)
+}
+
+const (
+	acceptHeaderKey         = "Accept"
+	negotiateViewContextKey = "iris.negotiateView"
+)
+
+// isTextualMediaType reports whether a charset should be added to the "mediaType"'s content type.
+func isTextualMediaType(mediaType string) bool {
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+
+	for _, suffix := range []string{"json", "xml", "yaml", "javascript"} {
+		if strings.HasSuffix(mediaType, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func renderJSON(ctx Context, v interface{}) error {
+	_, err := WriteJSON(ctx, v, DefaultJSONOptions, ctx.Application().ConfigurationReadOnly().GetEnableOptimizations())
+	return err
+}
+
+func renderXML(ctx Context, v interface{}) error {
+	_, err := WriteXML(ctx, v, DefaultXMLOptions)
+	return err
+}
+
+func renderYAML(ctx Context, v interface{}) error {
+	_, err := WriteYAML(ctx, v, DefaultYAMLOptions)
+	return err
+}
+
+func canRenderMsgPack(ctx Context, v interface{}) bool {
+	_, ok := v.(MsgPackMarshaler)
+	return ok || DefaultMsgPackOptions.Marshal != nil
+}
+
+func renderMsgPack(ctx Context, v interface{}) error {
+	_, err := WriteMsgPack(ctx, v, DefaultMsgPackOptions)
+	return err
+}
+
+func canRenderProtobuf(ctx Context, v interface{}) bool {
+	_, ok := v.(ProtobufMarshaler)
+	return ok || DefaultProtobufOptions.Marshal != nil
+}
+
+func renderProtobuf(ctx Context, v interface{}) error {
+	_, err := WriteProtobuf(ctx, v, DefaultProtobufOptions)
+	return err
+}
+
+// canRenderText reports whether the "v" has a text form,
+// a string, a number, a boolean, an error or a `fmt.Stringer` or an `encoding.TextMarshaler`.
+func canRenderText(ctx Context, v interface{}) bool {
+	switch v.(type) {
+	case string, []byte, error, fmt.Stringer, encoding.TextMarshaler:
+		return true
+	}
+
+	if v == nil {
+		return false
+	}
+
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+
+	return false
+}
+
+func renderText(ctx Context, v interface{}) (err error) {
+	switch value := v.(type) {
+	case []byte:
+		_, err = ctx.Write(value)
+	case error:
+		_, err = ctx.WriteString(value.Error())
+	case encoding.TextMarshaler:
+		var b []byte
+		if b, err = value.MarshalText(); err == nil {
+			_, err = ctx.Write(b)
+		}
+	default:
+		_, err = fmt.Fprint(ctx, v)
+	}
+
+	return
+}
+
+func canRenderView(ctx Context, v interface{}) bool {
+	return ctx.Values().GetString(negotiateViewContextKey) != ""
+}
+
+func renderView(ctx Context, v interface{}) error {
+	cfg := ctx.Application().ConfigurationReadOnly()
+	layout := ctx.Values().GetString(cfg.GetViewLayoutContextKey())
+	return ctx.Application().View(ctx, ctx.Values().GetString(negotiateViewContextKey), layout, v)
+}
@@ -0,0 +1,119 @@
+// black-box testing
+package context_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+type user struct {
+	Name string `json:"name" xml:"name" yaml:"name"`
+}
+
+// a protobuf-like message.
+type userMessage struct {
+	user
+}
+
+func (m userMessage) Marshal() ([]byte, error) {
+	return append([]byte{0x0a, byte(len(m.Name))}, m.Name...), nil
+}
+
+func TestNegotiate(t *testing.T) {
+	app := iris.New()
+	app.RegisterView(iris.HTML("./", ".html").Binary(func(name string) ([]byte, error) {
+		if name == "user.html" {
+			return []byte("<h1>{{.Name}}</h1>"), nil
+		}
+		return nil, os.ErrNotExist
+	}, func() []string {
+		return []string{"user.html"}
+	}))
+
+	app.Renderers().Register("text/csv", context.RendererFunc(func(ctx context.Context, v interface{}) error {
+		_, err := ctx.WriteString("name\n" + v.(user).Name + "\n")
+		return err
+	}))
+
+	app.Get("/user", func(ctx context.Context) {
+		ctx.NegotiateView("user.html")
+		ctx.Negotiate(user{"kataras"})
+	})
+	app.Get("/message", func(ctx context.Context) {
+		ctx.Negotiate(userMessage{user{"kataras"}})
+	})
+	app.Get("/json-xml", func(ctx context.Context) {
+		if err := ctx.Negotiate(user{"kataras"}, "application/json", "application/xml"); err != nil {
+			ctx.WriteString(err.Error())
+		}
+	})
+	app.Get("/text", func(ctx context.Context) {
+		ctx.Negotiate(errors.New("text"))
+	})
+
+	e := httptest.New(t, app)
+
+	expectJSON := func(accept string) {
+		e.GET("/user").WithHeader("Accept", accept).Expect().Status(httptest.StatusOK).
+			ContentType("application/json", "UTF-8").Body().Equal(`{"name":"kataras"}`)
+	}
+	// no "Accept", the first of the registered renderers.
+	expectJSON("")
+	expectJSON("*/*")
+	expectJSON("application/xml;q=0.5, application/json")
+	// same quality, the server's preference.
+	expectJSON("application/xml, application/json")
+
+	e.GET("/user").WithHeader("Accept", "application/xml;q=0.9, application/json;q=0.8").Expect().
+		Status(httptest.StatusOK).ContentType("application/xml", "UTF-8").
+		Body().Equal("<user><name>kataras</name></user>")
+	e.GET("/user").WithHeader("Accept", "application/x-yaml").Expect().
+		Status(httptest.StatusOK).ContentType("application/x-yaml", "UTF-8").
+		Body().Equal("name: kataras\n")
+	e.GET("/user").WithHeader("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8").Expect().
+		Status(httptest.StatusOK).ContentType("text/html", "UTF-8").
+		Body().Equal("<h1>kataras</h1>")
+	e.GET("/user").WithHeader("Accept", "text/csv").Expect().
+		Status(httptest.StatusOK).Body().Equal("name\nkataras\n")
+	e.GET("/user").WithHeader("Accept", "text/*;q=0.5, text/html, text/plain;q=0").Expect().
+		Status(httptest.StatusOK).ContentType("text/html")
+
+	// the value can't be written as protobuf or as text.
+	e.GET("/user").WithHeader("Accept", "application/x-protobuf, text/plain;q=0.5").Expect().
+		Status(httptest.StatusNotAcceptable).Header("Vary").Equal("Accept")
+	e.GET("/message").WithHeader("Accept", "application/x-protobuf, application/json;q=0.5").Expect().
+		Status(httptest.StatusOK).ContentType("application/x-protobuf", "").
+		Body().Equal("\x0a\x07kataras")
+
+	e.GET("/json-xml").WithHeader("Accept", "application/x-yaml, application/xml;q=0.1").Expect().
+		Status(httptest.StatusOK).ContentType("application/xml", "UTF-8")
+	e.GET("/json-xml").WithHeader("Accept", "application/x-yaml").Expect().
+		Status(httptest.StatusNotAcceptable).
+		Body().Equal(`none of the application/json, application/xml media types is acceptable by "application/x-yaml"`)
+
+	e.GET("/text").WithHeader("Accept", "application/json;q=0.1, text/plain").Expect().
+		Status(httptest.StatusOK).ContentType("text/plain", "UTF-8").Body().Equal("text")
+}
+
+func TestNegotiateNotAcceptableError(t *testing.T) {
+	app := iris.New()
+	app.Renderers().Remove("application/xml")
+
+	var err error
+	app.Get("/", func(ctx context.Context) {
+		err = ctx.Negotiate(user{"kataras"}, "application/json", "application/xml")
+	})
+
+	e := httptest.New(t, app)
+	e.GET("/").WithHeader("Accept", "application/xml").Expect().Status(httptest.StatusNotAcceptable)
+	if err == nil || !strings.Contains(err.Error(), `"application/xml"`) {
+		t.Fatalf("expected a not acceptable error but got: %v", err)
+	}
+}
@@ -1,5 +1,9 @@
 package context
 
+import (
+	"time"
+)
+
 // RouteReadOnly allows decoupled access to the current route
 // inside the context.
 type RouteReadOnly interface {
@@ -29,4 +33,57 @@ type RouteReadOnly interface {
 
 	// ResolvePath returns the formatted path's %v replaced with the args.
 	ResolvePath(args ...string) string
+
+	// Summary returns the route's short summary, if any.
+	Summary() string
+
+	// Description returns the route's description, if any.
+	Description() string
+
+	// Tags returns the route's tags, if any.
+	Tags() []string
+
+	// Deprecated reports whether the route is marked as deprecated.
+	Deprecated() bool
+
+	// DeprecatedSince returns the date that the route was deprecated,
+	// it's zero if the route is not deprecated or the date is unknown.
+	DeprecatedSince() time.Time
+
+	// Meta returns a read-only view of the route's custom attributes,
+	// i.e ctx.GetCurrentRoute().Meta().GetString("scope").
+	Meta() RouteMetaReadOnly
+}
+
+// RouteMetaReadOnly is the read-only view of a route's custom attributes,
+// they are shared between the requests so they can't be modified while serving.
+type RouteMetaReadOnly interface {
+	// Get returns the attribute's value based on its key, nil if not found.
+	Get(key string) interface{}
+	// GetDefault returns the attribute's value based on its key or the "def" if not found.
+	GetDefault(key string, def interface{}) interface{}
+	// GetString returns the attribute's value as string, empty if not found.
+	GetString(key string) string
+	// GetStringDefault returns the attribute's value as string or the "def" if not found.
+	GetStringDefault(key string, def string) string
+	// GetInt returns the attribute's value as int.
+	GetInt(key string) (int, error)
+	// GetIntDefault returns the attribute's value as int or the "def" if not found.
+	GetIntDefault(key string, def int) (int, error)
+	// GetInt64 returns the attribute's value as int64.
+	GetInt64(key string) (int64, error)
+	// GetInt64Default returns the attribute's value as int64 or the "def" if not found.
+	GetInt64Default(key string, def int64) (int64, error)
+	// GetFloat64 returns the attribute's value as float64.
+	GetFloat64(key string) (float64, error)
+	// GetFloat64Default returns the attribute's value as float64 or the "def" if not found.
+	GetFloat64Default(key string, def float64) (float64, error)
+	// GetBool returns the attribute's value as bool.
+	GetBool(key string) (bool, error)
+	// GetBoolDefault returns the attribute's value as bool or the "def" if not found.
+	GetBoolDefault(key string, def bool) (bool, error)
+	// Visit loops through each one of the attributes.
+	Visit(visitor func(key string, value interface{}))
+	// Len returns the number of the attributes.
+	Len() int
 }
@@ -0,0 +1,342 @@
+package context
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// UploadOptions contains the limits and the callbacks of the `Context#UploadFormFiles`,
+// the zero values mean no limits.
+type UploadOptions struct {
+	// MaxFileSize is the maximum size, in bytes, of each one of the files.
+	MaxFileSize int64
+	// MaxTotalSize is the maximum size, in bytes, of all of the files.
+	MaxTotalSize int64
+	// MaxFiles is the maximum number of the files.
+	MaxFiles int
+	// AllowedTypes are the allowed MIME types, or media ranges i.e "image/*",
+	// of the files, the types are sniffed from the files' contents,
+	// the parts' "Content-Type" and the file names are not trusted.
+	AllowedTypes []string
+	// Progress, if not nil, is called after each chunk of a file is written to the disk.
+	Progress func(p UploadProgress)
+}
+
+// UploadProgress is passed to the `UploadOptions#Progress`.
+type UploadProgress struct {
+	// FieldName is the form's key of the file.
+	FieldName string
+	// Filename is the sanitized file name.
+	Filename string
+	// Written is the number of the bytes of the file that are written so far.
+	Written int64
+	// TotalWritten is the number of the bytes of all of the files that are written so far.
+	TotalWritten int64
+}
+
+// UploadedFile is a file that is stored by the `Context#UploadFormFiles`.
+type UploadedFile struct {
+	// FieldName is the form's key of the file.
+	FieldName string `json:"fieldName"`
+	// OriginalFilename is the file name that the client sent.
+	OriginalFilename string `json:"originalFilename"`
+	// Filename is the sanitized, and unique inside the destination directory, file name.
+	Filename string `json:"filename"`
+	// Path is the full path of the stored file.
+	Path string `json:"path"`
+	// Size is the file's size in bytes.
+	Size int64 `json:"size"`
+	// ContentType is the sniffed MIME type of the file.
+	ContentType string `json:"contentType"`
+}
+
+var (
+	// ErrUploadTooLarge is returned by the `UploadFormFiles` when a file, or all of them,
+	// exceed the `UploadOptions` size limits or the files are too many.
+	// Check it with `ErrUploadTooLarge.Equal(err)`.
+	ErrUploadTooLarge = errors.New("upload: %s")
+	// ErrUploadTypeNotAllowed is returned by the `UploadFormFiles` when the sniffed MIME type
+	// of a file is not one of the `UploadOptions#AllowedTypes`.
+	// Check it with `ErrUploadTypeNotAllowed.Equal(err)`.
+	ErrUploadTypeNotAllowed = errors.New("upload: file %q of type %q is not allowed")
+)
+
+// sniffLen is the number of the bytes that are used to detect the content type,
+// see `http.DetectContentType`.
+const sniffLen = 512
+
+// UploadFormFiles streams all of the files of the multipart request body to the "destDir" directory,
+// each one of them is written to the disk while it's read, without keeping the whole file in memory.
+// The directory is created if it does not exist.
+//
+// The file names are sanitized and they are made unique, the existing files are never replaced.
+// The non-file form values are available through the `FormValue` and `PostValue` afterwards.
+//
+// The "opts" limit the size of each file, the total size, the number of the files and their sniffed MIME types
+// and report the progress. If a limit is exceeded, or on any other failure, all of the files
+// that were written by this call, including the partial ones, are removed and an error is returned,
+// see the `ErrUploadTooLarge` and `ErrUploadTypeNotAllowed`.
+//
+// It returns the stored files.
+func (ctx *context) UploadFormFiles(destDir string, opts UploadOptions) (files []UploadedFile, err error) {
+	reader, err := ctx.request.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(destDir, os.FileMode(0755)); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			for _, f := range files {
+				os.Remove(f.Path)
+			}
+			files = nil
+		}
+	}()
+
+	var (
+		total      int64
+		formValues = make(url.Values)
+		formSize   int64
+	)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return files, err
+		}
+
+		fieldName := part.FormName()
+		if part.FileName() == "" {
+			// a form value, limited as the `ParseMultipartForm`'s ones.
+			b, err := readAllLimit(part, DefaultMaxMemory-formSize)
+			part.Close()
+			if err != nil {
+				return files, err
+			}
+			formSize += int64(len(b))
+			formValues.Add(fieldName, string(b))
+			continue
+		}
+
+		if opts.MaxFiles > 0 && len(files) >= opts.MaxFiles {
+			part.Close()
+			return files, ErrUploadTooLarge.Format("more than " + strconv.Itoa(opts.MaxFiles) + " files")
+		}
+
+		file, err := ctx.uploadFormFile(part, destDir, opts, total)
+		part.Close()
+		if file.Path != "" { // created, even partially.
+			files = append(files, file)
+			total += file.Size
+		}
+		if err != nil {
+			return files, err
+		}
+	}
+
+	if ctx.request.PostForm == nil {
+		ctx.request.PostForm = make(url.Values)
+	}
+	if ctx.request.Form == nil {
+		ctx.request.Form = ctx.request.URL.Query()
+	}
+	for key, values := range formValues {
+		ctx.request.PostForm[key] = append(ctx.request.PostForm[key], values...)
+		ctx.request.Form[key] = append(ctx.request.Form[key], values...)
+	}
+
+	return files, nil
+}
+
+// uploadFormFile writes a file "part" to the "destDir", "total" is the size of the previous files.
+func (ctx *context) uploadFormFile(part *multipart.Part, destDir string, opts UploadOptions, total int64) (UploadedFile, error) {
+	file := UploadedFile{
+		FieldName:        part.FormName(),
+		OriginalFilename: part.FileName(),
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(part, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return file, err
+	}
+	head = head[:n]
+
+	file.ContentType = http.DetectContentType(head)
+	if mediaType, _, err := mime.ParseMediaType(file.ContentType); err == nil {
+		file.ContentType = mediaType
+	}
+
+	if !isAllowedType(opts.AllowedTypes, file.ContentType) {
+		return file, ErrUploadTypeNotAllowed.Format(file.OriginalFilename, file.ContentType)
+	}
+
+	out, err := createUniqueFile(destDir, SanitizeFilename(file.OriginalFilename))
+	if err != nil {
+		return file, err
+	}
+	defer out.Close()
+
+	file.Path = out.Name()
+	file.Filename = filepath.Base(file.Path)
+
+	w := &uploadWriter{
+		file:  &file,
+		out:   out,
+		opts:  opts,
+		total: total,
+	}
+
+	if _, err = w.Write(head); err != nil {
+		return file, err
+	}
+
+	if _, err = io.Copy(w, part); err != nil {
+		return file, err
+	}
+
+	return file, out.Close()
+}
+
+// uploadWriter writes a file to the disk, it checks the size limits and reports the progress.
+type uploadWriter struct {
+	file  *UploadedFile
+	out   *os.File
+	opts  UploadOptions
+	total int64
+}
+
+func (w *uploadWriter) Write(b []byte) (int, error) {
+	size := w.file.Size + int64(len(b))
+	if max := w.opts.MaxFileSize; max > 0 && size > max {
+		return 0, ErrUploadTooLarge.Format("file " + strconv.Quote(w.file.OriginalFilename) + " exceeds " + strconv.FormatInt(max, 10) + " bytes")
+	}
+
+	if max := w.opts.MaxTotalSize; max > 0 && w.total+size > max {
+		return 0, ErrUploadTooLarge.Format("files exceed " + strconv.FormatInt(max, 10) + " bytes")
+	}
+
+	n, err := w.out.Write(b)
+	w.file.Size += int64(n)
+	if err != nil {
+		return n, err
+	}
+
+	if w.opts.Progress != nil && n > 0 {
+		w.opts.Progress(UploadProgress{
+			FieldName:    w.file.FieldName,
+			Filename:     w.file.Filename,
+			Written:      w.file.Size,
+			TotalWritten: w.total + w.file.Size,
+		})
+	}
+
+	return n, nil
+}
+
+func isAllowedType(allowedTypes []string, contentType string) bool {
+	if len(allowedTypes) == 0 {
+		return true
+	}
+
+	for _, t := range allowedTypes {
+		if MediaTypeMatches(strings.ToLower(t), contentType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readAllLimit reads the "r" until EOF, it fails if it's more than "limit" bytes.
+func readAllLimit(r io.Reader, limit int64) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(b)) > limit {
+		return nil, ErrUploadTooLarge.Format("form values are too large")
+	}
+	return b, nil
+}
+
+// SanitizeFilename returns a safe file name based on the client's "filename":
+// the directories are removed, the control and the reserved characters are replaced by "_"
+// and the hidden and empty names are prefixed or replaced by "file".
+func SanitizeFilename(filename string) string {
+	// the clients may send full paths, with slashes or backslashes.
+	if idx := strings.LastIndexAny(filename, `/\`); idx != -1 {
+		filename = filename[idx+1:]
+	}
+
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`<>:"|?*`, r) || r == unicode.ReplacementChar {
+			return '_'
+		}
+		return r
+	}, filename)
+
+	filename = strings.TrimSpace(strings.TrimRight(filename, ". "))
+	if filename == "" {
+		return "file"
+	}
+
+	if filename[0] == '.' {
+		filename = "file" + filename
+	}
+
+	// keep it shorter than the common file systems' limit.
+	if len(filename) > 200 {
+		ext := filepath.Ext(filename)
+		if len(ext) > 20 {
+			ext = ""
+		}
+		end := 200 - len(ext)
+		for end > 0 && !utf8.RuneStart(filename[end]) {
+			end--
+		}
+		filename = filename[:end] + ext
+	}
+
+	return filename
+}
+
+// createUniqueFile creates a new file in the "dir" by the "filename",
+// if the file exists then a numeric suffix is added, i.e "report-1.pdf".
+func createUniqueFile(dir, filename string) (*os.File, error) {
+	ext := filepath.Ext(filename)
+	name := strings.TrimSuffix(filename, ext)
+
+	for i := 0; ; i++ {
+		candidate := filename
+		if i > 0 {
+			candidate = name + "-" + strconv.Itoa(i) + ext
+		}
+
+		f, err := os.OpenFile(filepath.Join(dir, candidate), os.O_WRONLY|os.O_CREATE|os.O_EXCL, os.FileMode(0644))
+		if os.IsExist(err) {
+			continue
+		}
+		return f, err
+	}
+}
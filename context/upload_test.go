@@ -0,0 +1,174 @@
+// black-box testing
+package context_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/errors"
+
+	"github.com/kataras/iris/httptest"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+// multipartBody returns a multipart body of the "files", by their names, and a "title" form value.
+func multipartBody(t *testing.T, files map[string][]byte) ([]byte, string) {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	w.WriteField("title", "reports")
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		part, err := w.CreateFormFile("files", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(files[name])
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return body.Bytes(), w.FormDataContentType()
+}
+
+func listDir(t *testing.T, dir string) []string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		names[i] = info.Name()
+	}
+	return names
+}
+
+func TestUploadFormFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "uploads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("existing"), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []context.UploadProgress
+	opts := context.UploadOptions{
+		MaxFileSize:  1024,
+		MaxTotalSize: 1500,
+		MaxFiles:     3,
+		AllowedTypes: []string{"image/*", "text/plain"},
+		Progress: func(p context.UploadProgress) {
+			progress = append(progress, p)
+		},
+	}
+
+	app := iris.New()
+	app.Post("/", func(ctx context.Context) {
+		files, err := ctx.UploadFormFiles(dir, opts)
+		if err != nil {
+			if e, ok := err.(errors.Error); ok && context.ErrUploadTooLarge.Equal(e) {
+				ctx.StatusCode(iris.StatusRequestEntityTooLarge)
+			} else if ok && context.ErrUploadTypeNotAllowed.Equal(e) {
+				ctx.StatusCode(iris.StatusUnsupportedMediaType)
+			} else {
+				ctx.StatusCode(iris.StatusBadRequest)
+			}
+			ctx.WriteString(err.Error())
+			return
+		}
+
+		for _, f := range files {
+			ctx.Writef("%s %s %s %d\n", f.OriginalFilename, f.Filename, f.ContentType, f.Size)
+		}
+		ctx.WriteString(ctx.FormValue("title"))
+	})
+
+	e := httptest.New(t, app)
+
+	body, contentType := multipartBody(t, map[string][]byte{
+		"notes.txt":          []byte("my notes"),
+		`C:\photos\cat?.png`: append(pngHeader, make([]byte, 600)...),
+	})
+	e.POST("/").WithHeader("Content-Type", contentType).WithBytes(body).Expect().
+		Status(httptest.StatusOK).Body().Equal(`C:\photos\cat?.png cat_.png image/png 608` + "\n" +
+		"notes.txt notes-1.txt text/plain 8\nreports")
+
+	if expected, got := "cat_.png,notes-1.txt,notes.txt", strings.Join(listDir(t, dir), ","); got != expected {
+		t.Fatalf("expected the files %q but got %q", expected, got)
+	}
+
+	if len(progress) == 0 {
+		t.Fatalf("expected the progress to be reported")
+	}
+	if last := progress[len(progress)-1]; last.Filename != "notes-1.txt" || last.Written != 8 || last.TotalWritten != 616 {
+		t.Fatalf("unexpected progress: %#v", last)
+	}
+
+	// the partial and the complete files of a failed upload are removed.
+	body, contentType = multipartBody(t, map[string][]byte{
+		"a.png": append(pngHeader, make([]byte, 800)...),
+		"b.png": append(pngHeader, make([]byte, 800)...),
+	})
+	e.POST("/").WithHeader("Content-Type", contentType).WithBytes(body).Expect().
+		Status(httptest.StatusRequestEntityTooLarge).Body().Equal("upload: files exceed 1500 bytes")
+
+	body, contentType = multipartBody(t, map[string][]byte{
+		"big.txt": bytes.Repeat([]byte("a"), 2048),
+	})
+	e.POST("/").WithHeader("Content-Type", contentType).WithBytes(body).Expect().
+		Status(httptest.StatusRequestEntityTooLarge).Body().Equal(`upload: file "big.txt" exceeds 1024 bytes`)
+
+	// the file name and the part's content type are not trusted.
+	body, contentType = multipartBody(t, map[string][]byte{
+		"a.png":    append(pngHeader, 1),
+		"page.png": []byte("<html><body>hi</body></html>"),
+	})
+	e.POST("/").WithHeader("Content-Type", contentType).WithBytes(body).Expect().
+		Status(httptest.StatusUnsupportedMediaType).
+		Body().Equal(`upload: file "page.png" of type "text/html" is not allowed`)
+
+	if expected, got := "cat_.png,notes-1.txt,notes.txt", strings.Join(listDir(t, dir), ","); got != expected {
+		t.Fatalf("expected the files %q but got %q", expected, got)
+	}
+
+	e.POST("/").WithHeader("Content-Type", "application/json").WithBytes([]byte("{}")).Expect().
+		Status(httptest.StatusBadRequest)
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":          "report.pdf",
+		"../../etc/passwd":    "passwd",
+		`..\..\boot.ini`:      "boot.ini",
+		".htaccess":           "file.htaccess",
+		"..":                  "file",
+		"":                    "file",
+		"a\x00b<c>:d|e*.txt ": "a_b_c__d_e_.txt",
+		"résumé.docx":         "résumé.docx",
+	}
+
+	for filename, expected := range tests {
+		if got := context.SanitizeFilename(filename); got != expected {
+			t.Fatalf("expected %q to be sanitized as %q but got %q", filename, expected, got)
+		}
+	}
+}
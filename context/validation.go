@@ -0,0 +1,367 @@
+package context
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldError describes a struct field that failed a validation rule.
+type FieldError struct {
+	// Field is the field's path, i.e "Email", "Address.City" or "Tags[1]".
+	Field string `json:"field" xml:"field" yaml:"Field"`
+	// Rule is the failed rule of the "validate" tag, i.e "required" or "min".
+	Rule string `json:"rule" xml:"rule" yaml:"Rule"`
+	// Param is the rule's parameter, i.e "3" for the "min=3".
+	Param string `json:"param,omitempty" xml:"param,omitempty" yaml:"Param,omitempty"`
+	// Message is a human readable description of the failure.
+	Message string `json:"message" xml:"message" yaml:"Message"`
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors is returned by the `Validate` and the `Context#ReadBody`
+// and it lists each one of the fields that failed validation,
+// it can be rendered as it's, i.e `ctx.StatusCode(iris.StatusUnprocessableEntity); ctx.JSON(errs)`.
+type ValidationErrors []FieldError
+
+// Error implements the error interface.
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validateTagKey is the struct tag of the validation rules, i.e `validate:"required,min=3"`.
+const validateTagKey = "validate"
+
+// Validate validates the struct, or the pointer to a struct, "v" based on the "validate" tags of its fields.
+// The rules are separated by commas:
+//
+//	required      the value should not be the zero one, i.e not empty
+//	omitempty     the rest of the rules are skipped if the value is the zero one
+//	min=n, max=n  the minimum and maximum number, or the length of a string, a slice or a map
+//	len=n         the exact number, or the exact length
+//	oneof=a b c   the value should be one of the space separated values
+//	email         the string should be an e-mail address
+//	regexp=expr   the string should match the regular expression, it should be the last rule
+//	dive          the next rules apply to the elements of a slice or a map
+//
+// The rules of a nil pointer which is not required are skipped, the rest of the values
+// are always checked, i.e a zero int against the "min", unless the "omitempty" is given.
+// The nested structs, and the structs of slices and maps, are validated too.
+//
+// It returns nil or the `ValidationErrors`.
+func Validate(v interface{}) error {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs ValidationErrors
+	validateStruct(val, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateStruct(v reflect.Value, prefix string, errs *ValidationErrors) {
+	typ := v.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" { // unexported.
+			continue
+		}
+
+		tag := f.Tag.Get(validateTagKey)
+		if tag == "-" {
+			continue
+		}
+
+		name := prefix + f.Name
+		if f.Anonymous {
+			name = strings.TrimSuffix(prefix, ".")
+		}
+
+		validateValue(v.Field(i), name, parseRules(tag), errs)
+	}
+}
+
+// validateValue validates the "v" by its "rules", the rules after a "dive" are applied to its elements.
+func validateValue(v reflect.Value, name string, rules []rule, errs *ValidationErrors) {
+	for i, r := range rules {
+		if r.name == "dive" {
+			validateElements(v, name, rules[i+1:], errs)
+			return
+		}
+
+		if r.name == "omitempty" {
+			if isEmptyValue(v) {
+				return
+			}
+			continue
+		}
+
+		if r.name != "required" && isNilValue(v) {
+			continue
+		}
+
+		if err := r.validate(v); err != nil {
+			*errs = append(*errs, FieldError{Field: name, Rule: r.name, Param: r.param, Message: err.Error()})
+			// the rest of the rules are not checked, i.e "required,email".
+			return
+		}
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		prefix := ""
+		if name != "" { // not an embedded struct.
+			prefix = name + "."
+		}
+		validateStruct(v, prefix, errs)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		validateElements(v, name, nil, errs)
+	}
+}
+
+func validateElements(v reflect.Value, name string, rules []rule, errs *ValidationErrors) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i, n := 0, v.Len(); i < n; i++ {
+			validateValue(v.Index(i), name+"["+strconv.Itoa(i)+"]", rules, errs)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			validateValue(v.MapIndex(key), fmt.Sprintf("%s[%v]", name, key.Interface()), rules, errs)
+		}
+	}
+}
+
+type rule struct {
+	name  string
+	param string
+}
+
+var (
+	rulesCache   = make(map[string][]rule)
+	rulesCacheMu sync.RWMutex
+)
+
+// parseRules parses the "validate" tag's value, the "regexp" rule takes the rest of it.
+func parseRules(tag string) []rule {
+	if tag == "" {
+		return nil
+	}
+
+	rulesCacheMu.RLock()
+	rules, ok := rulesCache[tag]
+	rulesCacheMu.RUnlock()
+	if ok {
+		return rules
+	}
+
+	s := tag
+	for s != "" {
+		part := s
+		if strings.HasPrefix(s, "regexp=") {
+			s = ""
+		} else if idx := strings.IndexByte(s, ','); idx != -1 {
+			part, s = s[:idx], s[idx+1:]
+		} else {
+			s = ""
+		}
+
+		r := rule{name: part}
+		if idx := strings.IndexByte(part, '='); idx != -1 {
+			r.name, r.param = part[:idx], part[idx+1:]
+		}
+		rules = append(rules, r)
+	}
+
+	rulesCacheMu.Lock()
+	rulesCache[tag] = rules
+	rulesCacheMu.Unlock()
+	return rules
+}
+
+var (
+	regexpsCache   = make(map[string]*regexp.Regexp)
+	regexpsCacheMu sync.RWMutex
+
+	emailRegexp = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	regexpsCacheMu.RLock()
+	re, ok := regexpsCache[expr]
+	regexpsCacheMu.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	regexpsCacheMu.Lock()
+	regexpsCache[expr] = re
+	regexpsCacheMu.Unlock()
+	return re, nil
+}
+
+func (r rule) validate(v reflect.Value) error {
+	switch r.name {
+	case "required":
+		if isEmptyValue(v) {
+			return fmt.Errorf("is required")
+		}
+	case "min", "max", "len":
+		return r.validateSize(v)
+	case "oneof":
+		value := fmt.Sprint(indirect(v).Interface())
+		for _, s := range strings.Fields(r.param) {
+			if s == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("should be one of [%s]", r.param)
+	case "email":
+		if !emailRegexp.MatchString(stringValue(v)) {
+			return fmt.Errorf("should be a valid e-mail address")
+		}
+	case "regexp":
+		re, err := compileRegexp(r.param)
+		if err != nil {
+			return fmt.Errorf("invalid regexp: %v", err)
+		}
+		if !re.MatchString(stringValue(v)) {
+			return fmt.Errorf("should match %s", r.param)
+		}
+	default:
+		return fmt.Errorf("unknown validation rule %q", r.name)
+	}
+
+	return nil
+}
+
+func (r rule) validateSize(v reflect.Value) error {
+	v = indirect(v)
+
+	var (
+		size    float64
+		isCount bool
+	)
+
+	switch v.Kind() {
+	case reflect.String:
+		size, isCount = float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size, isCount = float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	default:
+		return fmt.Errorf("%s is not supported by %s", r.name, v.Type())
+	}
+
+	limit, err := strconv.ParseFloat(r.param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s parameter %q", r.name, r.param)
+	}
+
+	what := "should be"
+	if isCount {
+		what = "length should be"
+	}
+
+	switch {
+	case r.name == "min" && size < limit:
+		return fmt.Errorf("%s at least %s", what, r.param)
+	case r.name == "max" && size > limit:
+		return fmt.Errorf("%s at most %s", what, r.param)
+	case r.name == "len" && size != limit:
+		return fmt.Errorf("%s %s", what, r.param)
+	}
+
+	return nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+func stringValue(v reflect.Value) string {
+	v = indirect(v)
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// isNilValue reports whether the "v" is a nil pointer or interface.
+func isNilValue(v reflect.Value) bool {
+	return (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()
+}
+
+// isEmptyValue reports whether the "v" is the zero value, a nil pointer or an empty string, slice or map.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Struct:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+	return false
+}
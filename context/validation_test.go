@@ -0,0 +1,142 @@
+// black-box testing
+package context_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/errors"
+
+	"github.com/kataras/iris/httptest"
+)
+
+type address struct {
+	City string `json:"city" xml:"city" yaml:"city" form:"city" validate:"required"`
+	Zip  string `json:"zip" xml:"zip" yaml:"zip" form:"zip" validate:"omitempty,len=5,regexp=^[0-9]{5}$"`
+}
+
+type signup struct {
+	Username string            `json:"username" xml:"username" yaml:"username" form:"username" validate:"required,min=3,max=12"`
+	Email    string            `json:"email" xml:"email" yaml:"email" form:"email" validate:"required,email"`
+	Age      int               `json:"age" xml:"age" yaml:"age" form:"age" validate:"min=18,max=130"`
+	Plan     string            `json:"plan" xml:"plan" yaml:"plan" form:"plan" validate:"omitempty,oneof=free pro"`
+	Tags     []string          `json:"tags" xml:"tags" yaml:"tags" form:"tags" validate:"max=3,dive,min=2"`
+	Address  *address          `json:"address" xml:"address" yaml:"address" form:"-"`
+	Others   []address         `json:"others" xml:"-" yaml:"others" form:"-"`
+	Meta     map[string]string `json:"meta" xml:"-" yaml:"meta" form:"-" validate:"dive,required"`
+}
+
+func newSignupApp() *iris.Application {
+	app := iris.New()
+	app.Post("/", func(ctx context.Context) {
+		var s signup
+		err := ctx.ReadBody(&s)
+		if err == nil {
+			ctx.Writef("%s %s %d %s %v", s.Username, s.Email, s.Age, s.Plan, s.Tags)
+			return
+		}
+
+		if errs, ok := err.(context.ValidationErrors); ok {
+			ctx.StatusCode(iris.StatusUnprocessableEntity)
+			ctx.JSON(errs)
+			return
+		}
+
+		if e, ok := err.(errors.Error); ok && context.ErrUnsupportedMediaType.Equal(e) {
+			ctx.StatusCode(iris.StatusUnsupportedMediaType)
+			return
+		}
+
+		ctx.StatusCode(iris.StatusBadRequest)
+	})
+	return app
+}
+
+func TestReadBody(t *testing.T) {
+	e := httptest.New(t, newSignupApp())
+
+	expected := "kataras kataras2006@hotmail.com 27 pro [go web]"
+
+	e.POST("/").WithJSON(map[string]interface{}{
+		"username": "kataras", "email": "kataras2006@hotmail.com", "age": 27, "plan": "pro", "tags": []string{"go", "web"},
+		"address": map[string]string{"city": "Athens", "zip": "10431"},
+	}).Expect().Status(httptest.StatusOK).Body().Equal(expected)
+
+	e.POST("/").WithHeader("Content-Type", "application/xml").
+		WithBytes([]byte(`<signup><username>kataras</username><email>kataras2006@hotmail.com</email><age>27</age><plan>pro</plan><tags>go</tags><tags>web</tags></signup>`)).
+		Expect().Status(httptest.StatusOK).Body().Equal(expected)
+
+	e.POST("/").WithHeader("Content-Type", "application/x-yaml; charset=utf-8").
+		WithBytes([]byte("username: kataras\nemail: kataras2006@hotmail.com\nage: 27\nplan: pro\ntags: [go, web]\n")).
+		Expect().Status(httptest.StatusOK).Body().Equal(expected)
+
+	e.POST("/").WithFormField("username", "kataras").WithFormField("email", "kataras2006@hotmail.com").
+		WithFormField("age", "27").WithFormField("plan", "pro").WithFormField("tags", "go").WithFormField("tags", "web").
+		Expect().Status(httptest.StatusOK).Body().Equal(expected)
+
+	e.POST("/").WithMultipart().WithFormField("username", "kataras").WithFormField("email", "kataras2006@hotmail.com").
+		WithFormField("age", "27").WithFormField("plan", "pro").WithFormField("tags", "go").WithFormField("tags", "web").
+		Expect().Status(httptest.StatusOK).Body().Equal(expected)
+
+	e.POST("/").WithHeader("Content-Type", "text/csv").WithBytes([]byte("kataras")).
+		Expect().Status(httptest.StatusUnsupportedMediaType)
+	e.POST("/").WithHeader("Content-Type", "application/json").WithBytes([]byte("{")).
+		Expect().Status(httptest.StatusBadRequest)
+}
+
+func TestReadBodyValidation(t *testing.T) {
+	e := httptest.New(t, newSignupApp())
+
+	e.POST("/").WithJSON(map[string]interface{}{
+		"username": "ka", "age": 16, "plan": "gold", "tags": []string{"go", "w", "x", "y"},
+		"address": map[string]string{"zip": "1043a"},
+		"others":  []map[string]string{{"city": "Athens", "zip": "10431"}, {"zip": "1234"}},
+		"meta":    map[string]string{"source": ""},
+	}).Expect().Status(httptest.StatusUnprocessableEntity).JSON().Equal([]map[string]string{
+		{"field": "Username", "rule": "min", "param": "3", "message": "length should be at least 3"},
+		{"field": "Email", "rule": "required", "message": "is required"},
+		{"field": "Age", "rule": "min", "param": "18", "message": "should be at least 18"},
+		{"field": "Plan", "rule": "oneof", "param": "free pro", "message": "should be one of [free pro]"},
+		{"field": "Tags", "rule": "max", "param": "3", "message": "length should be at most 3"},
+		{"field": "Address.City", "rule": "required", "message": "is required"},
+		{"field": "Address.Zip", "rule": "regexp", "param": "^[0-9]{5}$", "message": "should match ^[0-9]{5}$"},
+		{"field": "Others[1].City", "rule": "required", "message": "is required"},
+		{"field": "Others[1].Zip", "rule": "len", "param": "5", "message": "length should be 5"},
+		{"field": "Meta[source]", "rule": "required", "message": "is required"},
+	})
+
+	e.POST("/").WithJSON(map[string]interface{}{
+		"username": "kataras", "email": "kataras", "tags": []string{"go", "w"},
+	}).Expect().Status(httptest.StatusUnprocessableEntity).JSON().Equal([]map[string]string{
+		{"field": "Email", "rule": "email", "message": "should be a valid e-mail address"},
+		{"field": "Age", "rule": "min", "param": "18", "message": "should be at least 18"},
+		{"field": "Tags[1]", "rule": "min", "param": "2", "message": "length should be at least 2"},
+	})
+}
+
+func TestValidate(t *testing.T) {
+	type item struct {
+		Name  string  `validate:"required"`
+		Price float64 `validate:"min=0.5"`
+		Code  string  `validate:"omitempty,len=4"`
+	}
+
+	type order struct {
+		Items []*item `validate:"required,min=1"`
+	}
+
+	if err := context.Validate(&order{Items: []*item{{Name: "book", Price: 10}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := context.Validate(order{Items: []*item{{Price: 0.1}, nil, {Name: "pen", Code: "12345"}}})
+	if expected := "Items[0].Name: is required; Items[0].Price: should be at least 0.5; " +
+		"Items[2].Price: should be at least 0.5; Items[2].Code: length should be 4"; err == nil || err.Error() != expected {
+		t.Fatalf("expected the error %q but got: %v", expected, err)
+	}
+
+	if err = context.Validate(order{}); err == nil || err.Error() != "Items: is required" {
+		t.Fatalf("expected the items to be required but got: %v", err)
+	}
+}
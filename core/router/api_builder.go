@@ -4,13 +4,17 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kataras/iris/context"
 	"github.com/kataras/iris/core/errors"
 	"github.com/kataras/iris/core/router/macro"
 	"github.com/kataras/iris/mvc/activator"
+	"github.com/kataras/iris/mvc/activator/methodfunc"
 )
 
 const (
@@ -23,6 +27,9 @@ var (
 	// AllMethods contains the valid http methods:
 	// "GET", "POST", "PUT", "DELETE", "CONNECT", "HEAD",
 	// "PATCH", "OPTIONS", "TRACE".
+	//
+	// The custom methods, see `RegisterMethod`, are not part of it,
+	// use the `Methods` to get all of them.
 	AllMethods = [...]string{
 		"GET",
 		"POST",
@@ -39,14 +46,19 @@ var (
 // repository passed to all parties(subrouters), it's the object witch keeps
 // all the routes.
 type repository struct {
+	mu     sync.RWMutex // routes can be removed or replaced at serve-time.
 	routes []*Route
 }
 
 func (r *repository) register(route *Route) {
+	r.mu.Lock()
 	r.routes = append(r.routes, route)
+	r.mu.Unlock()
 }
 
 func (r *repository) get(routeName string) *Route {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	for _, r := range r.routes {
 		if r.Name == routeName {
 			return r
@@ -55,8 +67,61 @@ func (r *repository) get(routeName string) *Route {
 	return nil
 }
 
+// getByPath returns the route which is registered to the "method", "host", "subdomain",
+// the unparsed "path", i.e /users/{id:int}, and the API "version", otherwise nil.
+func (r *repository) getByPath(method, host, subdomain, path, version string) *Route {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, r := range r.routes {
+		if r.Method == method && r.Host == host && r.Subdomain == subdomain && r.tmpl.Src == path && r.Version == version {
+			return r
+		}
+	}
+	return nil
+}
+
+// getAll returns a copy of the registered routes,
+// the caller can sort them without affecting the registration order.
 func (r *repository) getAll() []*Route {
-	return r.routes
+	r.mu.RLock()
+	routes := make([]*Route, len(r.routes))
+	copy(routes, r.routes)
+	r.mu.RUnlock()
+	return routes
+}
+
+// remove removes the "route", returns false if it wasn't registered.
+func (r *repository) remove(route *Route) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.routes {
+		if existing == route {
+			r.routes = append(r.routes[:i], r.routes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// update calls the "fn" under the routes' lock, it's used to modify the registered routes.
+func (r *repository) update(fn func()) {
+	r.mu.Lock()
+	fn()
+	r.mu.Unlock()
+}
+
+// replace replaces the "old" route with the "route", at the same position,
+// if "old" is not registered then the "route" is registered as new.
+func (r *repository) replace(old *Route, route *Route) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.routes {
+		if existing == old {
+			r.routes[i] = route
+			return
+		}
+	}
+	r.routes = append(r.routes, route)
 }
 
 // APIBuilder the visible API for constructing the router
@@ -80,19 +145,36 @@ type APIBuilder struct {
 	// the per-party handlers, order
 	// of handlers registration matters.
 	middleware context.Handlers
+	// the names of the per-party, global and done handlers, by the same order,
+	// empty for the anonymous ones, see `RegisterMiddleware`.
+	middlewareNames  []string
+	beginGlobalNames []string
+	doneGlobalNames  []string
+	// the named middleware, shared between all parties.
+	namedMiddleware *middlewareRegistry
 	// the global middleware handlers, order of call doesn't matters, order
 	// of handlers registration matters. We need a secondary field for this
 	// because `UseGlobal` registers handlers that should be executed
 	// even before the `middleware` handlers, and in the same time keep the order
 	// of handlers registration, so the same type of handlers are being called in order.
 	beginGlobalHandlers context.Handlers
-	// the per-party routes registry (useful for `Done` and `UseGlobal` only)
-	apiRoutes []*Route
 	// the per-party done handlers, order
 	// of handlers registration matters.
 	doneGlobalHandlers context.Handlers
 	// the per-party
 	relativePath string
+	// the per-party full host name or host pattern, inherited by the children.
+	host string
+	// the per-party trailing slash policy, inherited by the children.
+	trailingSlash TrailingSlashPolicy
+	// the per-party path matching, inherited by the children.
+	pathMatching PathMatching
+	// the per-party API version and the default version, inherited by the children.
+	version        string
+	defaultVersion string
+	// the per-party deprecation, inherited by the children.
+	deprecated      bool
+	deprecatedSince time.Time
 }
 
 var _ Party = &APIBuilder{}
@@ -108,6 +190,7 @@ func NewAPIBuilder() *APIBuilder {
 		relativePath:      "/",
 		routes:            new(repository),
 	}
+	api.namedMiddleware = newMiddlewareRegistry(api.reporter)
 
 	return api
 }
@@ -135,17 +218,39 @@ func (api *APIBuilder) Handle(method string, relativePath string, handlers ...co
 		return api.Any(relativePath, handlers...)[0]
 	}
 
+	r := api.createRoute(method, relativePath, handlers...)
+	if r == nil {
+		return nil
+	}
+
+	// global, the `Done` and `UseGlobal` are applied to the routes of the repository.
+	api.routes.register(r)
+
+	return r
+}
+
+// fullPath returns the "relativePath" prefixed by this Party's path,
+// the result may contain a subdomain.
+func (api *APIBuilder) fullPath(relativePath string) string {
 	// no clean path yet because of subdomain indicator/separator which contains a dot.
 	// but remove the first slash if the relative has already ending with a slash
 	// it's not needed because later on we do normalize/clean the path, but better do it here too
 	// for any future updates.
 	if api.relativePath[len(api.relativePath)-1] == '/' {
-		if relativePath[0] == '/' {
+		if len(relativePath) > 0 && relativePath[0] == '/' {
 			relativePath = relativePath[1:]
 		}
 	}
 
-	fullpath := api.relativePath + relativePath // for now, keep the last "/" if any,  "/xyz/"
+	return api.relativePath + relativePath // for now, keep the last "/" if any,  "/xyz/"
+}
+
+// createRoute creates a new route based on this Party's path and handlers,
+// without registering it.
+//
+// Returns nil if the route is not valid, the error is being reported.
+func (api *APIBuilder) createRoute(method string, relativePath string, handlers ...context.Handler) *Route {
+	fullpath := api.fullPath(relativePath)
 	if len(handlers) == 0 {
 		api.reporter.Add("missing handlers for route %s: %s", method, fullpath)
 		return nil
@@ -161,6 +266,7 @@ func (api *APIBuilder) Handle(method string, relativePath string, handlers ...co
 	if len(api.doneGlobalHandlers) > 0 {
 		routeHandlers = append(routeHandlers, api.doneGlobalHandlers...) // register the done middleware, if any
 	}
+	routeHandlerNames := joinNames(joinNames(api.middlewareNames, unnamed(len(handlers))), api.doneGlobalNames)
 
 	// here we separate the subdomain and relative path
 	subdomain, path := splitSubdomainAndPath(fullpath)
@@ -171,15 +277,115 @@ func (api *APIBuilder) Handle(method string, relativePath string, handlers ...co
 		return nil
 	}
 
+	// the macro's handler, if any, is prepended to the handlers by the `NewRoute`.
+	macroHandlers := len(r.Handlers) - len(routeHandlers)
+	r.handlerNames = joinNames(unnamed(macroHandlers), routeHandlerNames)
+	r.mainHandlerIndex = macroHandlers + len(api.middleware)
+	r.namedMiddleware = api.namedMiddleware
+
+	r.Host = api.host
+	r.TrailingSlash = api.trailingSlash
+	r.PathMatching = api.pathMatching
+	r.SourceFileName, r.SourceLineNumber = getCaller()
+	r.Version = api.version
+	r.defaultVersion = api.defaultVersion
+	r.Name = r.defaultName()
+	if api.deprecated {
+		r.Deprecate(api.deprecatedSince)
+	}
+
 	// Add UseGlobal Handlers
-	r.use(api.beginGlobalHandlers)
+	r.use(api.beginGlobalHandlers, api.beginGlobalNames)
 
-	// global
-	api.routes.register(r)
+	return r
+}
+
+// irisDir is the directory of the framework's source code,
+// the calls from its packages are skipped by the `getCaller`.
+var irisDir = func() string {
+	_, file, _, _ := runtime.Caller(0) // core/router/api_builder.go
+	return filepath.Dir(filepath.Dir(filepath.Dir(file))) + string(filepath.Separator)
+}()
+
+// getCaller returns the file and the line of the first caller outside of the framework,
+// i.e the line that registered a route, the framework's tests and examples are not skipped.
+func getCaller() (string, int) {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		file := filepath.FromSlash(frame.File)
+		internal := strings.HasPrefix(file, irisDir) &&
+			!strings.HasSuffix(file, "_test.go") &&
+			!strings.Contains(file, string(filepath.Separator)+"_examples"+string(filepath.Separator))
+
+		// the controllers' methods are registered through reflection.
+		if !internal && !strings.HasPrefix(frame.Function, "runtime.") && !strings.HasPrefix(frame.Function, "reflect.") {
+			return file, frame.Line
+		}
+
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+// RemoveRoute removes a registered route based on its name, i.e "GET/users/{id:int}" or a custom one.
+// Returns true if the route was found and removed.
+func (api *APIBuilder) RemoveRoute(routeName string) bool {
+	r := api.routes.get(routeName)
+	if r == nil {
+		return false
+	}
+
+	return api.routes.remove(r)
+}
 
-	// per -party, used for done handlers
-	api.apiRoutes = append(api.apiRoutes, r)
+// RemoveRouteByPath removes a registered route based on its http method and its path,
+// the "relativePath" is relative to this Party's one, as the `Handle`'s, i.e "/users/{id:int}",
+// only a route of this Party's host and version, see `Host` and `Version`, can be removed.
+// Returns true if the route was found and removed.
+func (api *APIBuilder) RemoveRouteByPath(method string, relativePath string) bool {
+	subdomain, path := splitSubdomainAndPath(api.fullPath(relativePath))
+	r := api.routes.getByPath(method, api.host, subdomain, path, api.version)
+	if r == nil {
+		return false
+	}
+
+	return api.routes.remove(r)
+}
+
+// RemoveRoutes removes the "routes", by their identity and not by their names,
+// i.e the routes that are returned by the `Handle`.
+// Returns the number of the routes that were found and removed.
+func (api *APIBuilder) RemoveRoutes(routes ...*Route) int {
+	n := 0
+	for _, r := range routes {
+		if r != nil && api.routes.remove(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// ReplaceRoute registers a route as the `Handle` does but if a route
+// with the same http method and path is already registered, to this Party's host and version, then it replaces that route,
+// the new route takes its name, its metadata and its position.
+//
+// Returns the new *Route, app will throw any errors later on.
+func (api *APIBuilder) ReplaceRoute(method string, relativePath string, handlers ...context.Handler) *Route {
+	r := api.createRoute(method, relativePath, handlers...)
+	if r == nil {
+		return nil
+	}
+
+	old := api.routes.getByPath(r.Method, r.Host, r.Subdomain, r.tmpl.Src, r.Version)
+	if old != nil {
+		r.Name = old.Name
+		r.copyMetadata(old)
+	}
 
+	api.routes.replace(old, r)
 	return r
 }
 
@@ -255,6 +461,7 @@ func (api *APIBuilder) Party(relativePath string, handlers ...context.Handler) P
 	fullpath := parentPath + relativePath
 	// append the parent's + child's handlers
 	middleware := joinHandlers(api.middleware, handlers)
+	middlewareNames := joinNames(api.middlewareNames, unnamed(len(handlers)))
 
 	return &APIBuilder{
 		// global/api builder
@@ -263,10 +470,22 @@ func (api *APIBuilder) Party(relativePath string, handlers ...context.Handler) P
 		errorCodeHandlers:   api.errorCodeHandlers,
 		beginGlobalHandlers: api.beginGlobalHandlers,
 		doneGlobalHandlers:  api.doneGlobalHandlers,
+		beginGlobalNames:    api.beginGlobalNames,
+		doneGlobalNames:     api.doneGlobalNames,
+		namedMiddleware:     api.namedMiddleware,
 		reporter:            api.reporter,
 		// per-party/children
-		middleware:   middleware,
-		relativePath: fullpath,
+		middleware:      middleware,
+		middlewareNames: middlewareNames,
+		relativePath:    fullpath,
+		host:            api.host,
+		trailingSlash:   api.trailingSlash,
+		pathMatching:    api.pathMatching,
+		// versioning
+		version:         api.version,
+		defaultVersion:  api.defaultVersion,
+		deprecated:      api.deprecated,
+		deprecatedSince: api.deprecatedSince,
 	}
 }
 
@@ -297,6 +516,9 @@ func (api *APIBuilder) PartyFunc(relativePath string, partyBuilderFunc func(p Pa
 //
 // If called from a child party then the subdomain will be prepended to the path instead of appended.
 // So if app.Subdomain("admin.").Subdomain("panel.") then the result is: "panel.admin.".
+//
+// The subdomain's labels can be parameters, with the same syntax as the path's parameters,
+// i.e "{tenant:alphabetical}." or "{region}.{tenant}.", their values are stored to the `Context#Params`.
 func (api *APIBuilder) Subdomain(subdomain string, middleware ...context.Handler) Party {
 	if api.relativePath == SubdomainWildcardIndicator {
 		// cannot concat wildcard subdomain with something else
@@ -307,6 +529,33 @@ func (api *APIBuilder) Subdomain(subdomain string, middleware ...context.Handler
 	return api.Party(subdomain, middleware...)
 }
 
+// Host returns a new party which is responsible to register routes to
+// a full host name, i.e "shop.com", or to a host pattern, i.e "*.shop.*".
+//
+// The routes of a host are resolved before the path routing and before the routes that are not bound to a host,
+// the full host names are resolved first, then the host patterns by registration order.
+// The party can have its own middleware and error code handlers, as any other party.
+//
+// The pattern's syntax is the same as the `path#Match`'s one, the "*" matches any sequence of characters,
+// the host is compared without its port, lowercased.
+//
+// Usage:
+// shop := app.Host("shop.com")
+// shop.Get("/", shopIndex)
+// app.Host("*.shop.*").Get("/", localizedShopIndex)
+// app.Host("admin.internal", adminAuth).Get("/", adminIndex)
+func (api *APIBuilder) Host(host string, middleware ...context.Handler) Party {
+	host = strings.ToLower(host)
+	if _, err := path.Match(host, ""); err != nil || host == "" {
+		api.reporter.Add("invalid host: %s", host)
+		return api
+	}
+
+	p := api.Party("/", middleware...).(*APIBuilder)
+	p.host = host
+	return p
+}
+
 // WildcardSubdomain returns a new party which is responsible to register routes to
 // a dynamic, wildcard(ed) subdomain. A dynamic subdomain is a subdomain which
 // can reply to any subdomain requests. Server will accept any subdomain
@@ -343,6 +592,11 @@ func (api *APIBuilder) GetRoute(routeName string) *Route {
 	return api.routes.get(routeName)
 }
 
+// updateRoutes calls the "fn" under the registered routes' lock, see `routesUpdater`.
+func (api *APIBuilder) updateRoutes(fn func()) {
+	api.routes.update(fn)
+}
+
 // GetRouteReadOnly returns the registered "read-only" route based on its name, otherwise nil.
 // One note: "routeName" should be case-sensitive. Used by the context to get the current route.
 // It returns an interface instead to reduce wrong usage and to keep the decoupled design between
@@ -366,17 +620,53 @@ func (api *APIBuilder) GetRouteReadOnly(routeName string) context.RouteReadOnly
 // Use `UseGlobal` if you want to register begin handlers(middleware)
 // that should be always run before all application's routes.
 func (api *APIBuilder) Use(handlers ...context.Handler) {
+	api.use(handlers, unnamed(len(handlers)))
+}
+
+func (api *APIBuilder) use(handlers context.Handlers, names []string) {
 	api.middleware = append(api.middleware, handlers...)
+	api.middlewareNames = joinNames(api.middlewareNames, names)
+}
+
+// RegisterMiddleware registers a "handler" by its "name", i.e "auth",
+// the named middleware can be used by the `UseNamed`, `UseGlobalNamed`, `DoneNamed` and `Route#UseNamed`
+// and a route can opt out of them through the `Route#Except`.
+// The names are shared between all of the Parties, a name can't be registered twice.
+//
+// Usage:
+// app.RegisterMiddleware("auth", authMiddleware)
+// api := app.Party("/api")
+// api.UseNamed("auth")
+// api.Get("/health", health).Except("auth")
+func (api *APIBuilder) RegisterMiddleware(name string, handler context.Handler) {
+	api.namedMiddleware.register(name, handler)
+}
+
+// UseNamed same as `Use` but it appends the middleware that are registered by their "names",
+// see `RegisterMiddleware`.
+func (api *APIBuilder) UseNamed(names ...string) {
+	api.use(api.namedMiddleware.get(names))
 }
 
 // Done appends to the very end, Handler(s) to the current Party's routes and child routes
 // The difference from .Use is that this/or these Handler(s) are being always running last.
 func (api *APIBuilder) Done(handlers ...context.Handler) {
-	for _, r := range api.routes.routes {
-		r.done(handlers) // append the handlers to the existing routes
+	api.done(handlers, unnamed(len(handlers)))
+}
+
+// DoneNamed same as `Done` but it appends the middleware that are registered by their "names",
+// see `RegisterMiddleware`.
+func (api *APIBuilder) DoneNamed(names ...string) {
+	api.done(api.namedMiddleware.get(names))
+}
+
+func (api *APIBuilder) done(handlers context.Handlers, names []string) {
+	for _, r := range api.routes.getAll() {
+		r.done(handlers, names) // append the handlers to the existing routes
 	}
 	// set as done handlers for the next routes as well.
 	api.doneGlobalHandlers = append(api.doneGlobalHandlers, handlers...)
+	api.doneGlobalNames = joinNames(api.doneGlobalNames, names)
 }
 
 // UseGlobal registers handlers that should run before all routes,
@@ -387,11 +677,22 @@ func (api *APIBuilder) Done(handlers ...context.Handler) {
 //
 // It's always a good practise to call it right before the `Application#Run` function.
 func (api *APIBuilder) UseGlobal(handlers ...context.Handler) {
-	for _, r := range api.routes.routes {
-		r.use(handlers) // prepend the handlers to the existing routes
+	api.useGlobal(handlers, unnamed(len(handlers)))
+}
+
+// UseGlobalNamed same as `UseGlobal` but it registers the middleware that are registered by their "names",
+// see `RegisterMiddleware`.
+func (api *APIBuilder) UseGlobalNamed(names ...string) {
+	api.useGlobal(api.namedMiddleware.get(names))
+}
+
+func (api *APIBuilder) useGlobal(handlers context.Handlers, names []string) {
+	for _, r := range api.routes.getAll() {
+		r.use(handlers, names) // prepend the handlers to the existing routes
 	}
 	// set as begin handlers for the next routes as well.
 	api.beginGlobalHandlers = append(api.beginGlobalHandlers, handlers...)
+	api.beginGlobalNames = joinNames(api.beginGlobalNames, names)
 }
 
 // None registers an "offline" route
@@ -468,9 +769,10 @@ func (api *APIBuilder) Trace(relativePath string, handlers ...context.Handler) *
 }
 
 // Any registers a route for ALL of the http methods
-// (Get,Post,Put,Head,Patch,Options,Connect,Delete).
+// (Get,Post,Put,Head,Patch,Options,Connect,Delete)
+// and the custom ones that are registered through the `RegisterMethod`.
 func (api *APIBuilder) Any(relativePath string, handlers ...context.Handler) (routes []*Route) {
-	for _, m := range AllMethods {
+	for _, m := range Methods() {
 		r := api.HandleMany(m, relativePath, handlers...)
 		routes = append(routes, r...)
 	}
@@ -536,9 +838,14 @@ func (api *APIBuilder) Any(relativePath string, handlers ...context.Handler) (ro
 func (api *APIBuilder) Controller(relativePath string, controller activator.BaseController,
 	bindValues ...interface{}) (routes []*Route) {
 
+	// the registered routes by their http method and relative path,
+	// in order to be described by the controller's method functions that they're registered from.
+	registered := make(map[string][]*Route)
+
 	registerFunc := func(ifRelPath string, method string, handlers ...context.Handler) {
 		relPath := relativePath + ifRelPath
 		r := api.HandleMany(method, relPath, handlers...)
+		registered[method+ifRelPath] = append(registered[method+ifRelPath], r...)
 		routes = append(routes, r...)
 	}
 
@@ -550,10 +857,22 @@ func (api *APIBuilder) Controller(relativePath string, controller activator.Base
 	// and tag them with `iris:"persistence"`.
 	//
 	// don't worry it will never be handled if empty values.
-	if err := activator.Register(controller, bindValues, registerFunc); err != nil {
+	// the controller's method functions can be mapped to the custom methods too.
+	opts := methodfunc.Options{Macros: api.macros, Methods: Methods()}
+	methodFuncs, err := activator.RegisterWithOptions(controller, bindValues, opts, registerFunc)
+	if err != nil {
 		api.reporter.Add("%v for path: '%s'", err, relativePath)
 	}
 
+	for _, m := range methodFuncs {
+		info := m.FuncInfo
+		for _, route := range registered[m.HTTPMethod+m.RelPath] {
+			if route != nil && route.ControllerMethod == nil {
+				route.ControllerMethod = &info
+			}
+		}
+	}
+
 	return
 }
 
@@ -790,11 +1109,17 @@ func (api *APIBuilder) StaticWeb(requestPath string, systemPath string) *Route {
 // the body if recorder was enabled
 // and/or disable the gzip if gzip response recorder
 // was active.
+//
+// The handlers are scoped to this Party's subdomain and path,
+// i.e `app.Party("/api").OnErrorCode(404, ...)` handles the not found requests under the "/api" only,
+// the rest of the requests are handled by the parent's error code handlers.
 func (api *APIBuilder) OnErrorCode(statusCode int, handlers ...context.Handler) {
 	if len(api.beginGlobalHandlers) > 0 {
 		handlers = joinHandlers(api.beginGlobalHandlers, handlers)
 	}
 
+	subdomain, path := splitSubdomainAndPath(api.fullPath("/"))
+	api.errorCodeHandlers = api.errorCodeHandlers.scope(api.host, subdomain, path, api.macros)
 	api.errorCodeHandlers.Register(statusCode, handlers...)
 }
 
@@ -852,7 +1177,8 @@ func (api *APIBuilder) OnAnyErrorCode(handlers ...context.Handler) {
 }
 
 // FireErrorCode executes an error http status code handler
-// based on the context's status code.
+// based on the context's status code,
+// the handler of the most specific Party that matches the request's subdomain and path is preferred.
 //
 // If a handler is not already registered,
 // then it creates & registers a new trivial handler on the-fly.
@@ -879,6 +1205,82 @@ func (api *APIBuilder) Layout(tmplLayoutFile string) Party {
 	return api
 }
 
+// TrailingSlash sets the policy for the requests with a trailing slash, i.e "/orders/",
+// to this Party's routes and its children's routes that are registered after this call.
+//
+// The policy can be one of the:
+// `TrailingSlashRedirect`, `TrailingSlashMatch` and `TrailingSlashStrict`,
+// the `TrailingSlashDefault` follows the `Configuration#DisablePathCorrection`.
+//
+// Returns this Party, to continue as normal.
+// Usage:
+// api := app.Party("/api").TrailingSlash(iris.TrailingSlashMatch)
+func (api *APIBuilder) TrailingSlash(policy TrailingSlashPolicy) Party {
+	api.trailingSlash = policy
+	return api
+}
+
+// PathMatching sets how the request paths that are not matching exactly a route's path, i.e "/Products/ABC",
+// are matched to this Party's routes and its children's routes that are registered after this call.
+//
+// The "matching" can be a combination of the:
+// `PathMatchCaseInsensitive`, `PathMatchNormalize` and `PathMatchRedirect`,
+// it replaces the configuration's ones, the `PathMatchExact` matches the registered path only
+// and the `PathMatchDefault` follows the `Configuration#EnableCaseInsensitivePaths`,
+// `Configuration#EnablePathNormalization` and `Configuration#EnableCanonicalPathRedirect`.
+//
+// Returns this Party, to continue as normal.
+// Usage:
+// products := app.Party("/products").PathMatching(iris.PathMatchCaseInsensitive | iris.PathMatchRedirect)
+func (api *APIBuilder) PathMatching(matching PathMatching) Party {
+	api.pathMatching = matching
+	return api
+}
+
+// Version returns a new Party, with the same path, which registers its routes for the API "version", i.e "1.2.0".
+//
+// Routes that are sharing the same method and path are selected at serve time
+// based on the requested version range, i.e "1.2.0", "1", "^1.2" or ">= 1.0, < 3",
+// the latest version which satisfies the range is served.
+// The range is read from the "Accept-Version" header or from the "version" parameter of
+// the "Accept" header's media types, i.e "application/vnd.api+json; version=1".
+// See `DefaultVersion` for requests without a version.
+//
+// The resolved version is available through the `Context#GetVersion`.
+//
+// Usage:
+// v1 := app.Version("1.0.0").Deprecate(time.Time{})
+// v1.Get("/users", getUsersV1)
+// v2 := app.Version("2.0.0")
+// v2.Get("/users", getUsersV2)
+func (api *APIBuilder) Version(version string, middleware ...context.Handler) Party {
+	p := api.Party("/", middleware...).(*APIBuilder)
+	p.version = version
+	return p
+}
+
+// DefaultVersion sets the version range, i.e "1" or "1.2.0", that is served
+// when the request doesn't ask for a specific version,
+// to this Party's routes and its children's routes that are registered after this call.
+// Defaults to the latest version.
+//
+// Returns this Party, to continue as normal.
+func (api *APIBuilder) DefaultVersion(version string) Party {
+	api.defaultVersion = version
+	return api
+}
+
+// Deprecate marks this Party's routes and its children's routes that are registered after this call
+// as deprecated since the "date", the "date" can be zero if it's unknown.
+// The versioned routes, see `Version`, send a "Deprecation" response header.
+//
+// Returns this Party, to continue as normal.
+func (api *APIBuilder) Deprecate(date time.Time) Party {
+	api.deprecated = true
+	api.deprecatedSince = date
+	return api
+}
+
 // joinHandlers uses to create a copy of all Handlers and return them in order to use inside the node
 func joinHandlers(Handlers1 context.Handlers, Handlers2 context.Handlers) context.Handlers {
 	nowLen := len(Handlers1)
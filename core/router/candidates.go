@@ -0,0 +1,172 @@
+package router
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kataras/iris/context"
+)
+
+// candidateRoutes are the routes that are sharing the same method, subdomain and path
+// but they are registered for different API versions or media types,
+// one of them is selected at serve time based on the request's
+// "Accept-Version", "Content-Type" and "Accept" headers.
+type candidateRoutes struct {
+	// the constrained routes first, by registration order.
+	routes []*Route
+	// the parsed versions of the routes, by the same order, nil for the unversioned ones.
+	versions []*semver
+	// the range of the versions that are served when the request's version is missing,
+	// if nil then the latest version is served.
+	defaultVersion versionRange
+	versioned      bool
+	vary           string
+}
+
+func newCandidateRoutes(routes []*Route) (*candidateRoutes, error) {
+	// the constrained routes are tried first.
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].constrained() && !routes[j].constrained()
+	})
+
+	c := &candidateRoutes{routes: routes, versions: make([]*semver, len(routes))}
+
+	var vary []string
+	for i, r := range routes {
+		if len(r.Produces) > 0 && len(vary) == 0 {
+			vary = append(vary, "Accept")
+		}
+
+		if r.Version == "" {
+			continue
+		}
+
+		v, err := parseVersion(r.Version)
+		if err != nil {
+			return nil, err
+		}
+		c.versions[i] = &v
+		c.versioned = true
+
+		if c.defaultVersion == nil && r.defaultVersion != "" {
+			if c.defaultVersion, err = parseVersionRange(r.defaultVersion); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if c.versioned {
+		vary = append(vary, AcceptVersionHeaderKey)
+	}
+	c.vary = strings.Join(vary, ", ")
+
+	return c, nil
+}
+
+// handler returns the handler which selects and executes the route
+// that is registered for the requested version, it can consume the request's body
+// and it can produce the most acceptable response.
+//
+// It fires the 400 status code when the requested version is invalid,
+// the 501 when there is no route for the requested version,
+// the 415 when none of the routes can consume the request's body
+// and the 406 when none of the routes can produce an acceptable response.
+func (c *candidateRoutes) handler() context.Handler {
+	return func(ctx context.Context) {
+		if c.vary != "" {
+			ctx.Header("Vary", c.vary)
+		}
+
+		routes := c.routes
+		if c.versioned {
+			if routes = c.selectVersion(ctx); len(routes) == 0 {
+				return
+			}
+		}
+
+		contentType := ctx.GetHeader("Content-Type")
+		if idx := strings.IndexByte(contentType, ';'); idx != -1 {
+			contentType = contentType[:idx]
+		}
+		contentType = strings.ToLower(strings.TrimSpace(contentType))
+		// a request without a body, i.e a GET or a DELETE, has nothing to consume.
+		bodyless := contentType == "" && !hasBody(ctx.Request())
+
+		accepted := context.ParseAccept(ctx.GetHeader("Accept"))
+
+		var (
+			selected   *Route
+			best       float64
+			consumable bool
+		)
+
+		for _, r := range routes {
+			if !bodyless && !r.consumes(contentType) {
+				continue
+			}
+			consumable = true
+
+			if q := r.quality(accepted); q > best {
+				selected, best = r, q
+			}
+		}
+
+		if selected == nil {
+			if consumable {
+				ctx.StatusCode(http.StatusNotAcceptable)
+			} else {
+				ctx.StatusCode(http.StatusUnsupportedMediaType)
+			}
+			return
+		}
+
+		if selected.Version != "" {
+			ctx.SetVersion(selected.Version)
+			if selected.Deprecated {
+				deprecation := "true"
+				if !selected.DeprecatedSince.IsZero() {
+					deprecation = selected.DeprecatedSince.UTC().Format(http.TimeFormat)
+				}
+				ctx.Header("Deprecation", deprecation)
+			}
+		}
+
+		ctx.SetCurrentRoute(routeReadOnlyWrapper{selected})
+		ctx.Do(selected.Handlers)
+	}
+}
+
+// selectVersion returns the routes of the latest version that satisfies the request's version range,
+// including the unversioned routes.
+// It fires the 400 or 501 status code and returns nil if the requested version is invalid or missing.
+func (c *candidateRoutes) selectVersion(ctx context.Context) []*Route {
+	r := c.defaultVersion
+	if requested := requestedVersion(ctx); requested != "" {
+		var err error
+		if r, err = parseVersionRange(requested); err != nil {
+			ctx.StatusCode(http.StatusBadRequest)
+			return nil
+		}
+	}
+
+	var latest *semver
+	for _, v := range c.versions {
+		if v != nil && (r == nil || r.check(*v)) && (latest == nil || v.compare(*latest) > 0) {
+			latest = v
+		}
+	}
+
+	var routes []*Route
+	for i, v := range c.versions {
+		if v == nil || latest != nil && v.compare(*latest) == 0 {
+			routes = append(routes, c.routes[i])
+		}
+	}
+
+	if len(routes) == 0 {
+		ctx.StatusCode(http.StatusNotImplemented)
+	}
+
+	return routes
+}
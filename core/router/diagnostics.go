@@ -0,0 +1,200 @@
+package router
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/kataras/iris/core/router/macro/interpreter/ast"
+	"github.com/kataras/iris/core/router/macro/interpreter/parser"
+)
+
+// Diagnose reports the routes that are registered but they can't be served as they were declared:
+// the routes with path parameters that can never match because of their conflicting functions, i.e {id:int min(10) max(5)},
+// the routes that are shadowed by a route which is registered before them on the same path
+// and accepts all of their parameters' values, i.e /users/{id:int} shadows the /users/{id:int min(1)}
+// and the routes of a Party which its host is matched by the host pattern of a Party which is registered before it.
+//
+// The routes should be already built, the warnings are reported by the route's `Trace`,
+// which contains the file and the line of the route's registration.
+// Returns nil if nothing to report.
+//
+// See `Configuration#EnableRouteWarningsAsErrors` too.
+func Diagnose(routes []*Route) error {
+	rp := errors.NewReporter()
+
+	var (
+		// the routes by their method, host, subdomain and unnamed path, by order.
+		positions = make(map[string][]*Route)
+		// the host patterns by the order of their first route.
+		hostPatterns []string
+	)
+
+	for _, r := range routes {
+		diagnoseParams(rp, r)
+
+		position := r.Method + r.Host + "|" + r.Subdomain + unnamedPath(r.Path)
+		if !r.constrained() {
+			for _, earlier := range positions[position] {
+				if shadows(earlier, r) {
+					rp.Add("%s is shadowed by %s, which is registered before it and accepts all of its path parameters' values",
+						r.Trace(), earlier.Trace())
+					break
+				}
+			}
+			positions[position] = append(positions[position], r)
+		}
+
+		if isHostPattern(r.Host) && !containsString(hostPatterns, r.Host) {
+			hostPatterns = append(hostPatterns, r.Host)
+		}
+	}
+
+	for _, r := range routes {
+		if isHostPattern(r.Host) {
+			for _, earlier := range hostPatterns {
+				if earlier == r.Host {
+					break
+				}
+				// the host pattern of the route is matched by the earlier one, i.e "*.shop.com" by "*.com".
+				if ok, _ := path.Match(earlier, r.Host); ok {
+					rp.Add("%s is unreachable, its host pattern is matched by the host pattern %q which is registered before it",
+						r.Trace(), earlier)
+					break
+				}
+			}
+			continue
+		}
+
+		if r.Host == "" {
+			for _, pattern := range hostPatterns {
+				if strings.Trim(pattern, "*") == "" {
+					rp.Add("%s is unreachable, all of the hosts are matched by the host pattern %q", r.Trace(), pattern)
+					break
+				}
+			}
+		}
+	}
+
+	return rp.Return()
+}
+
+// diagnoseParams reports the path parameters of the route that can never match,
+// their functions are limiting the value (or its length) to an empty range, i.e min(10) max(5).
+func diagnoseParams(rp *errors.Reporter, r *Route) {
+	stmts, err := parser.Parse(r.tmpl.Src)
+	if err != nil {
+		return
+	}
+
+	for _, stmt := range stmts {
+		lower, upper, ok := paramBounds(stmt)
+		if ok && lower > upper {
+			rp.Add("%s: the parameter %s can never match, its functions are conflicting", r.Trace(), stmt.Src)
+		}
+	}
+}
+
+// paramBounds returns the range of the integer value, or of the length, of the path parameter's value,
+// based on the builtin "min", "max" and "range" functions.
+// Returns false if the parameter's type is a custom one.
+func paramBounds(stmt *ast.ParamStatement) (lower, upper int, ok bool) {
+	lower, upper = 0, int(^uint(0)>>1)
+	if stmt.Type.IsCustom() || stmt.Type == ast.ParamTypeBoolean {
+		return lower, upper, false
+	}
+
+	for _, fn := range stmt.Funcs {
+		args := make([]int, len(fn.Args))
+		for i, arg := range fn.Args {
+			n, err := ast.ParamFuncArgToInt(arg)
+			if err != nil {
+				return lower, upper, false
+			}
+			args[i] = n
+		}
+
+		switch {
+		case fn.Name == "min" && len(args) == 1 && args[0] > lower:
+			lower = args[0]
+		case fn.Name == "max" && len(args) == 1 && args[0] < upper:
+			upper = args[0]
+		case fn.Name == "range" && len(args) == 2 && (stmt.Type == ast.ParamTypeInt || stmt.Type == ast.ParamTypeLong):
+			if args[0] > lower {
+				lower = args[0]
+			}
+			if args[1] < upper {
+				upper = args[1]
+			}
+		}
+	}
+
+	return lower, upper, true
+}
+
+// shadows reports whether the "earlier" route, which is tried before the "r" by the router's tree,
+// accepts all of the path parameters' values that the "r" accepts.
+// The routes are registered to the same method, host, subdomain and path.
+func shadows(earlier, r *Route) bool {
+	if paramsSignature(earlier.tmpl) == paramsSignature(r.tmpl) {
+		return false // it's a conflict, it's reported on build.
+	}
+
+	// the routes with evaluated parameters are tried first.
+	earlierEvaluators := convertTmplToNodeEvaluators(earlier.tmpl)
+	evaluators := convertTmplToNodeEvaluators(r.tmpl)
+	if earlierEvaluators == nil || evaluators == nil || len(earlier.tmpl.Params) != len(r.tmpl.Params) {
+		return false
+	}
+
+	earlierFuncs, funcs := paramFuncs(earlier.tmpl.Src), paramFuncs(r.tmpl.Src)
+	if len(earlierFuncs) != len(earlierEvaluators) || len(funcs) != len(evaluators) {
+		return false
+	}
+
+	for i, eval := range earlierEvaluators {
+		if eval == nil {
+			continue // accepts anything.
+		}
+
+		if evaluators[i] == nil || earlier.tmpl.Params[i].Type != r.tmpl.Params[i].Type {
+			return false
+		}
+
+		// the earlier's functions should be a subset of the route's ones.
+		for _, fn := range earlierFuncs[i] {
+			if !containsString(funcs[i], fn) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// paramFuncs returns the functions of the path parameters, i.e "min(1)", by the parameters' order.
+func paramFuncs(src string) [][]string {
+	stmts, err := parser.Parse(src)
+	if err != nil {
+		return nil
+	}
+
+	funcs := make([][]string, len(stmts))
+	for i, stmt := range stmts {
+		for _, fn := range stmt.Funcs {
+			funcs[i] = append(funcs[i], fmt.Sprintf("%s%v", fn.Name, fn.Args))
+		}
+	}
+
+	return funcs
+}
+
+func containsString(slice []string, s string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
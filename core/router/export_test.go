@@ -0,0 +1,9 @@
+package router
+
+// ResetCustomMethods removes the methods that are registered through the `RegisterMethod`,
+// the tests that are registering custom methods should call it when they're done.
+func ResetCustomMethods() {
+	methodsMu.Lock()
+	customMethods = nil
+	methodsMu.Unlock()
+}
@@ -1,11 +1,13 @@
 package router
 
 import (
+	"fmt"
 	"github.com/kataras/golog"
-	"html"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/kataras/iris/context"
 
@@ -29,20 +31,82 @@ type tree struct {
 	// subdomain is empty for default-hostname routes,
 	// ex: mysubdomain.
 	Subdomain string
-	Nodes     *node.Nodes
+	// subdomainTmpl is not nil if the subdomain has parameters, i.e "{tenant}.".
+	subdomainTmpl *subdomainTemplate
+	Nodes         *node.Tree
 }
 
-type routerHandler struct {
+// canHandle reports whether the tree's subdomain matches the request's host,
+// the parameters of a dynamic subdomain are not stored to the context's params, see `routerTrees#findFunc`.
+func (t *tree) canHandle(ctx context.Context) bool {
+	if t.subdomainTmpl != nil {
+		return t.subdomainTmpl.matches(ctx)
+	}
+	return canHandleSubdomain(ctx, t.Subdomain)
+}
+
+// routerTrees is the result of the routerHandler's Build.
+type routerTrees struct {
+	// the trees by registration order, sorted by their subdomains' priority.
 	trees []*tree
-	hosts bool // true if at least one route contains a Subdomain.
+	// the same trees grouped by their method, so a request
+	// is resolved by the trees of its method only.
+	// The methods are a few, a slice is faster than a map to look up.
+	methods []methodTrees
+	hosts   bool // true if at least one route contains a Subdomain.
+	// the added routes by their keys in the trees, see `add`,
+	// the routes are added by a unique key and not by their name
+	// because more than one routes can share the same name, i.e a custom one.
+	routes map[string]*Route
+	// the trailing slash policies of the routes, by their keys,
+	// the routes with the default policy are not stored.
+	trailingSlash map[string]TrailingSlashPolicy
+	// the path matching of the routes, by their keys, and all of them combined,
+	// the routes with the default path matching are not stored.
+	pathMatching    map[string]PathMatching
+	pathMatchingAny PathMatching
+	// the trees of the routes that are bound to a full host name or to a host pattern,
+	// they're resolved before the rest of the trees, see `forHost`.
+	exactHosts   map[string]*routerTrees
+	hostPatterns []hostTrees
+}
+
+// methodTrees are the trees of a method, by the same order of the `routerTrees#trees`.
+type methodTrees struct {
+	method string
+	trees  []*tree
+}
+
+// byMethod returns the trees of the "method".
+func (h *routerTrees) byMethod(method string) []*tree {
+	for i := range h.methods {
+		if h.methods[i].method == method {
+			return h.methods[i].trees
+		}
+	}
+	return nil
+}
+
+type routerHandler struct {
+	// the built *routerTrees, they're replaced as a whole on each `Build`
+	// so the in-flight requests keep using the previous trees
+	// and the new requests are being served by the new ones.
+	current atomic.Value
 }
 
 var _ RequestHandler = &routerHandler{}
 
-func (h *routerHandler) getTree(method, subdomain string) *tree {
-	for i := range h.trees {
-		t := h.trees[i]
-		if t.Method == method && t.Subdomain == subdomain {
+// load returns the current built trees, safe for concurrent use.
+func (h *routerHandler) load() *routerTrees {
+	if t, ok := h.current.Load().(*routerTrees); ok {
+		return t
+	}
+	return &routerTrees{} // not built yet.
+}
+
+func (h *routerTrees) getTree(method, subdomain string) *tree {
+	for _, t := range h.byMethod(method) {
+		if t.Subdomain == subdomain {
 			return t
 		}
 	}
@@ -50,24 +114,82 @@ func (h *routerHandler) getTree(method, subdomain string) *tree {
 	return nil
 }
 
-func (h *routerHandler) addRoute(r *Route) error {
+func (h *routerTrees) addRoute(r *Route) error {
+	return h.add(r, r.Handlers)
+}
+
+// addCandidateRoutes registers the routes that are sharing the same method, subdomain and path
+// as one, its handler selects the route based on the request's version and media types.
+func (h *routerTrees) addCandidateRoutes(routes []*Route) error {
+	c, err := newCandidateRoutes(routes)
+	if err != nil {
+		return err
+	}
+	return h.add(routes[0], context.Handlers{c.handler()})
+}
+
+func (h *routerTrees) add(r *Route, handlers context.Handlers) error {
+	if r.Host != "" {
+		h = h.getHost(r.Host)
+	}
+
+	if h.routes == nil {
+		h.routes = make(map[string]*Route)
+	}
+
+	// the route's name is its key, unless it's taken by an other route.
+	routeName := r.Name
+	if _, taken := h.routes[routeName]; taken {
+		routeName += "#" + strconv.Itoa(len(h.routes))
+	}
+	h.routes[routeName] = r
+
 	var (
-		routeName = r.Name
-		method    = r.Method
-		subdomain = r.Subdomain
-		path      = r.Path
-		handlers  = r.Handlers
+		method     = r.Method
+		subdomain  = r.Subdomain
+		path       = r.Path
+		evaluators = convertTmplToNodeEvaluators(r.tmpl)
 	)
 
+	if r.TrailingSlash != TrailingSlashDefault {
+		if h.trailingSlash == nil {
+			h.trailingSlash = make(map[string]TrailingSlashPolicy)
+		}
+		h.trailingSlash[routeName] = r.TrailingSlash
+	}
+
+	if r.PathMatching != PathMatchDefault {
+		if h.pathMatching == nil {
+			h.pathMatching = make(map[string]PathMatching)
+		}
+		h.pathMatching[routeName] = r.PathMatching
+		h.pathMatchingAny |= r.PathMatching
+	}
+
+	if subdomain != "" {
+		h.hosts = true
+	}
+
 	t := h.getTree(method, subdomain)
 
 	if t == nil {
-		n := node.Nodes{}
 		// first time we register a route to this method with this subdomain
-		t = &tree{Method: method, Subdomain: subdomain, Nodes: &n}
+		t = &tree{Method: method, Subdomain: subdomain, subdomainTmpl: r.subdomainTmpl, Nodes: new(node.Tree)}
 		h.trees = append(h.trees, t)
+		h.addToMethod(t)
+	}
+	return t.Nodes.AddRoute(routeName, routeReadOnlyWrapper{r}, path, handlers, evaluators...)
+}
+
+// addToMethod appends the "t" to the trees of its method.
+func (h *routerTrees) addToMethod(t *tree) {
+	for i := range h.methods {
+		if h.methods[i].method == t.Method {
+			h.methods[i].trees = append(h.methods[i].trees, t)
+			return
+		}
 	}
-	return t.Nodes.Add(routeName, path, handlers)
+	h.methods = append(h.methods, methodTrees{method: t.Method, trees: []*tree{t}})
 }
 
 // NewDefaultHandler returns the handler which is responsible
@@ -84,15 +206,24 @@ type RoutesProvider interface { // api builder
 	GetRoute(routeName string) *Route
 }
 
+// routesUpdater is implemented by the `APIBuilder`,
+// the built handlers are written back to its registered routes under its routes' lock.
+type routesUpdater interface {
+	updateRoutes(fn func())
+}
+
 func (h *routerHandler) Build(provider RoutesProvider) error {
 	registeredRoutes := provider.GetRoutes()
-	h.trees = h.trees[0:0] // reset, inneed when rebuilding.
+	// build new trees, the current ones may still serve requests.
+	built := &routerTrees{}
 
 	// sort, subdomains goes first.
-	sort.Slice(registeredRoutes, func(i, j int) bool {
+	// Stable because the registration order matters
+	// for routes that are sharing the same path with different macro param types.
+	sort.SliceStable(registeredRoutes, func(i, j int) bool {
 		first, second := registeredRoutes[i], registeredRoutes[j]
-		lsub1 := len(first.Subdomain)
-		lsub2 := len(second.Subdomain)
+		lsub1 := subdomainPriority(first.Subdomain)
+		lsub2 := subdomainPriority(second.Subdomain)
 
 		firstSlashLen := strings.Count(first.Path, "/")
 		secondSlashLen := strings.Count(second.Path, "/")
@@ -121,126 +252,375 @@ func (h *routerHandler) Build(provider RoutesProvider) error {
 	})
 
 	rp := errors.NewReporter()
+	// keep track of the registered routes per method, subdomain, path and param types
+	// in order to report the ones that can't be differentiated at serve time,
+	// the routes with different versions or media types are grouped in order to be selected at serve time.
+	var signatures []string
+	groups := make(map[string][]*Route)
+	// the registered routes and their built copies, by the same order.
+	builtRoutes := make([]*Route, len(registeredRoutes))
+
+	for i, r := range registeredRoutes {
+		// build the r.Handlers based on begin and done handlers, if any,
+		// to a copy which is swapped in together with the new trees.
+		r = r.built()
+		builtRoutes[i] = r
+
+		signature := r.Method + r.Host + "|" + r.Subdomain + unnamedPath(r.Path) + paramsSignature(r.tmpl)
+		group, ok := groups[signature]
+		if !ok {
+			signatures = append(signatures, signature)
+		}
+
+		conflicts := false
+		for _, existing := range group {
+			// the grouped routes are served by the same tree's path, so by the same param names.
+			if !sameParamNames(existing.tmpl, r.tmpl) {
+				rp.Add("%v -> %s conflicts with %s, their path parameters have different names",
+					node.ErrDublicate, r.String(), existing.String())
+				conflicts = true
+				break
+			}
+
+			if existing.Version == r.Version && existing.mediaTypesSignature() == r.mediaTypesSignature() {
+				rp.Add("%v -> %s conflicts with %s, their path parameters are evaluated the same way",
+					node.ErrDublicate, r.String(), existing.String())
+				conflicts = true
+				break
+			}
+		}
+		if conflicts {
+			continue
+		}
 
-	for _, r := range registeredRoutes {
-		// build the r.Handlers based on begin and done handlers, if any.
-		r.BuildHandlers()
+		groups[signature] = append(group, r)
+	}
 
-		if r.Subdomain != "" {
-			h.hosts = true
+	for _, signature := range signatures {
+		group := groups[signature]
+
+		var err error
+		if len(group) > 1 || group[0].constrained() {
+			err = built.addCandidateRoutes(group)
+		} else {
+			// the only "bad" with this is if the user made an error
+			// on route, it will be stacked shown in this build state
+			// and no in the lines of the user's action, they should read
+			// the docs better. Or TODO: add a link here in order to help new users.
+			err = built.addRoute(group[0])
 		}
 
-		// the only "bad" with this is if the user made an error
-		// on route, it will be stacked shown in this build state
-		// and no in the lines of the user's action, they should read
-		// the docs better. Or TODO: add a link here in order to help new users.
-		if err := h.addRoute(r); err != nil {
+		if err != nil {
 			// node errors:
-			rp.Add("%v -> %s", err, r.String())
+			rp.Add("%v -> %s", err, group[0].String())
 			continue
 		}
-		golog.Debugf(r.Trace())
+
+		for _, r := range group {
+			golog.Debugf(r.Trace())
+		}
 	}
 
-	return rp.Return()
+	if err := rp.Return(); err != nil {
+		return err
+	}
+
+	// replace the trees only when the build succeed.
+	h.current.Store(built)
+
+	// keep the built handlers readable from the registered routes too, i.e by `GetRoutes`.
+	writeBack := func() {
+		for i, r := range registeredRoutes {
+			r.setBuilt(builtRoutes[i])
+		}
+	}
+	if u, ok := provider.(routesUpdater); ok {
+		u.updateRoutes(writeBack)
+	} else {
+		writeBack()
+	}
+
+	return nil
+}
+
+// subdomainPriority returns the priority of a subdomain's tree,
+// the static subdomains go first, then the dynamic ones, then the wildcard and the root domain is the last one,
+// a longer subdomain goes first between the same kind of subdomains.
+func subdomainPriority(subdomain string) int {
+	switch {
+	case subdomain == "":
+		return 0
+	case subdomain == SubdomainWildcardIndicator:
+		return 1
+	case isDynamicSubdomain(subdomain):
+		return 1<<16 + len(subdomain)
+	default:
+		return 1<<17 + len(subdomain)
+	}
+}
+
+// unnamedPath returns the underline router's path without the param names,
+// i.e /users/:id/*file -> /users/:/*.
+func unnamedPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) == 0 {
+			continue
+		}
+		if segment[0] == ParamStart[0] || segment[0] == WildcardParamStart[0] {
+			segments[i] = segment[0:1]
+		}
+	}
+	return strings.Join(segments, "/")
 }
 
 func (h *routerHandler) HandleRequest(ctx context.Context) {
 	method := ctx.Method()
 	path := ctx.Path()
-	if !ctx.Application().ConfigurationReadOnly().GetDisablePathCorrection() {
+	trees := h.load().forHost(ctx)
+
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		// the policy of the route that serves the path without the trailing slash,
+		// if any, otherwise the configuration's one.
+		policy := TrailingSlashRedirect
+		if ctx.Application().ConfigurationReadOnly().GetDisablePathCorrection() {
+			policy = TrailingSlashStrict
+		}
 
-		if len(path) > 1 && path[len(path)-1] == '/' {
+		trimmed := path[:len(path)-1]
+		// the path parameters are not stored, the route may not serve the request.
+		if routeName, ok := trees.exists(ctx, method, trimmed); ok {
+			if p, ok := trees.trailingSlash[routeName]; ok {
+				policy = p
+			}
+		}
+
+		switch policy {
+		case TrailingSlashRedirect:
 			// Remove trailing slash and client-permant rule for redirection,
-			// if confgiuration allows that and path has an extra slash.
+			// if configuration allows that and path has an extra slash.
+			redirectPermanently(ctx, trimmed)
+			return
+		case TrailingSlashMatch:
+			path = trimmed
+		}
+	}
+
+	if route, handlers := trees.find(ctx, method, path); len(handlers) > 0 {
+		ctx.SetCurrentRoute(route)
+		ctx.Do(handlers)
+		// found
+		return
+	}
+
+	// the configuration is not needed by the requests that are served by the exact path.
+	config := ctx.Application().ConfigurationReadOnly()
 
-			// update the new path and redirect.
-			r := ctx.Request()
-			path = path[:len(path)-1]
-			r.URL.Path = path
-			url := r.URL.String()
+	if route, handlers, redirect := trees.findLoose(ctx, method, path, pathMatching(config)); len(handlers) > 0 {
+		ctx.SetCurrentRoute(route)
+		if redirect {
+			if canonical := canonicalPath(ctx); canonical != "" && canonical != path {
+				redirectPermanently(ctx, canonical)
+				return
+			}
+		}
 
-			ctx.Redirect(url, http.StatusMovedPermanently)
+		ctx.Do(handlers)
+		return
+	}
 
-			// RFC2616 recommends that a short note "SHOULD" be included in the
-			// response because older user agents may not understand 301/307.
-			// Shouldn't send the response for POST or HEAD; that leaves GET.
-			if method == http.MethodGet {
-				note := "<a href=\"" +
-					html.EscapeString(url) +
-					"\">Moved Permanently</a>.\n"
+	if method == http.MethodHead && config.GetEnableAutoHead() {
+		if route, handlers := trees.find(ctx, http.MethodGet, path); len(handlers) > 0 {
+			// serve the HEAD through the GET route's handlers,
+			// the response's body is discarded but its headers and its length are kept.
+			w := &headResponseWriter{ResponseWriter: ctx.ResponseWriter()}
+			ctx.ResetResponseWriter(w)
+			ctx.SetCurrentRoute(route)
+			ctx.Do(handlers)
 
-				ctx.ResponseWriter().WriteString(note)
+			if w.length > 0 && w.Header().Get("Content-Length") == "" {
+				// no effect if the headers are already flushed by the handlers.
+				w.Header().Set("Content-Length", strconv.Itoa(w.length))
 			}
 			return
 		}
 	}
 
-	for i := range h.trees {
-		t := h.trees[i]
-		if method != t.Method {
+	if method == http.MethodOptions && config.GetEnableAutoOptions() {
+		if allow := trees.allowedMethods(ctx, path); len(allow) > 0 {
+			ctx.Header("Allow", strings.Join(allow, ", "))
+			ctx.StatusCode(http.StatusOK)
+			return
+		}
+	}
+
+	if config.GetFireMethodNotAllowed() {
+		// a bit slower than previous implementation but @kataras let me to apply this change
+		// because it's more reliable.
+		//
+		// if `Configuration#FireMethodNotAllowed` is kept as defaulted(false) then this function will not
+		// run, therefore performance kept as before.
+		if allow := trees.allowedMethods(ctx, path); len(allow) > 0 {
+			// RCF rfc2616 https://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html
+			// The response MUST include an Allow header containing a list of valid methods for the requested resource.
+			ctx.Header("Allow", strings.Join(allow, ", "))
+			ctx.StatusCode(http.StatusMethodNotAllowed)
+			return
+		}
+	}
+
+	ctx.StatusCode(http.StatusNotFound)
+}
+
+// headResponseWriter is the response writer of the automatic HEAD requests,
+// see `Configuration#EnableAutoHead`, it discards the body that the GET route's handlers
+// are writing but it keeps its length, the body is not buffered.
+type headResponseWriter struct {
+	context.ResponseWriter
+	length int
+}
+
+func (w *headResponseWriter) Write(contents []byte) (int, error) {
+	w.length += len(contents)
+	return len(contents), nil
+}
+
+func (w *headResponseWriter) WriteString(s string) (int, error) {
+	w.length += len(s)
+	return len(s), nil
+}
+
+func (w *headResponseWriter) Writef(format string, a ...interface{}) (int, error) {
+	return fmt.Fprintf(w, format, a...)
+}
+
+// Written returns the length of the discarded body, if any, so the error code handlers
+// are not fired for a response which has a body, the same as the GET ones.
+func (w *headResponseWriter) Written() int {
+	if w.length > 0 {
+		return w.length
+	}
+	return w.ResponseWriter.Written()
+}
+
+// find returns the "read-only" route and handlers that can serve the "method" and "path",
+// the path and the dynamic subdomain's parameters are stored to the context's params.
+// Returns nil handlers if not found or method not allowed.
+func (h *routerTrees) find(ctx context.Context, method, path string) (context.RouteReadOnly, context.Handlers) {
+	_, route, handlers := h.findFunc(ctx, method, path, false, nil)
+	return route, handlers
+}
+
+// exists same as `find` but it doesn't store any parameters to the context's params,
+// it returns the route's key and true if a route can serve the "method" and "path".
+func (h *routerTrees) exists(ctx context.Context, method, path string) (string, bool) {
+	for _, t := range h.byMethod(method) {
+		if h.hosts && t.Subdomain != "" && !t.canHandle(ctx) {
 			continue
 		}
 
-		if h.hosts && t.Subdomain != "" {
-			requestHost := ctx.Host()
-			if netutil.IsLoopbackSubdomain(requestHost) {
-				// this fixes a bug when listening on
-				// 127.0.0.1:8080 for example
-				// and have a wildcard subdomain and a route registered to root domain.
-				continue // it's not a subdomain, it's something like 127.0.0.1 probably
-			}
-			// it's a dynamic wildcard subdomain, we have just to check if ctx.subdomain is not empty
-			if t.Subdomain == SubdomainWildcardIndicator {
-				// mydomain.com -> invalid
-				// localhost -> invalid
-				// sub.mydomain.com -> valid
-				// sub.localhost -> valid
-				serverHost := ctx.Application().ConfigurationReadOnly().GetVHost()
-				if serverHost == requestHost {
-					continue // it's not a subdomain, it's a full domain (with .com...)
-				}
+		// not found or method not allowed if the first tree can't serve it.
+		return t.Nodes.Exists(path)
+	}
 
-				dotIdx := strings.IndexByte(requestHost, '.')
-				slashIdx := strings.IndexByte(requestHost, '/')
-				if dotIdx > 0 && (slashIdx == -1 || slashIdx > dotIdx) {
-					// if "." was found anywhere but not at the first path segment (host).
-				} else {
-					continue
-				}
-				// continue to that, any subdomain is valid.
-			} else if !strings.HasPrefix(requestHost, t.Subdomain) { // t.Subdomain contains the dot.
-				continue
-			}
+	return "", false
+}
+
+// findFunc same as `find` but the static parts of the path are compared case-insensitively if "caseInsensitive" is true
+// and the routes that can serve the "path" are filtered by the optional "accept", see `node.Tree#FindRoute`.
+// It returns the route's key in the trees too, see `add`.
+func (h *routerTrees) findFunc(ctx context.Context, method, path string, caseInsensitive bool, accept func(routeName string) bool) (string, context.RouteReadOnly, context.Handlers) {
+	for _, t := range h.byMethod(method) {
+		if h.hosts && t.Subdomain != "" && !t.canHandle(ctx) {
+			continue
 		}
-		routeName, handlers := t.Nodes.Find(path, ctx.Params())
+
+		routeName, route, handlers := t.Nodes.FindRoute(path, ctx.Params(), caseInsensitive, accept)
 		if len(handlers) > 0 {
-			ctx.SetCurrentRouteName(routeName)
-			ctx.Do(handlers)
-			// found
-			return
+			if t.subdomainTmpl != nil {
+				t.subdomainTmpl.storeParams(ctx)
+			}
+			return routeName, route, handlers
 		}
 		// not found or method not allowed.
 		break
 	}
 
-	if ctx.Application().ConfigurationReadOnly().GetFireMethodNotAllowed() {
-		for i := range h.trees {
-			t := h.trees[i]
-			// a bit slower than previous implementation but @kataras let me to apply this change
-			// because it's more reliable.
-			//
-			// if `Configuration#FireMethodNotAllowed` is kept as defaulted(false) then this function will not
-			// run, therefore performance kept as before.
-			if t.Nodes.Exists(path) {
-				// RCF rfc2616 https://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html
-				// The response MUST include an Allow header containing a list of valid methods for the requested resource.
-				ctx.Header("Allow", t.Method)
-				ctx.StatusCode(http.StatusMethodNotAllowed)
-				return
+	return "", nil, nil
+}
+
+// allowedMethods returns the http methods that the "path" can be served by,
+// including the automatic HEAD and OPTIONS if enabled by the configuration.
+// Returns an empty slice if the "path" can't be served by any of the registered routes.
+func (h *routerTrees) allowedMethods(ctx context.Context, path string) []string {
+	var allow []string
+	has := func(method string) bool {
+		for _, m := range allow {
+			if m == method {
+				return true
 			}
 		}
+		return false
 	}
 
-	ctx.StatusCode(http.StatusNotFound)
+	for i := range h.trees {
+		t := h.trees[i]
+		if has(t.Method) {
+			continue
+		}
+
+		if h.hosts && t.Subdomain != "" && !t.canHandle(ctx) {
+			continue
+		}
+
+		if _, ok := t.Nodes.Exists(path); ok {
+			allow = append(allow, t.Method)
+		}
+	}
+
+	if len(allow) == 0 {
+		return allow
+	}
+
+	config := ctx.Application().ConfigurationReadOnly()
+	if config.GetEnableAutoHead() && has(http.MethodGet) && !has(http.MethodHead) {
+		allow = append(allow, http.MethodHead)
+	}
+
+	if config.GetEnableAutoOptions() && !has(http.MethodOptions) {
+		allow = append(allow, http.MethodOptions)
+	}
+
+	return allow
+}
+
+// canHandleSubdomain returns true if the request's host matches the "subdomain",
+// which is not empty and it contains the dot, or it's the wildcard subdomain.
+func canHandleSubdomain(ctx context.Context, subdomain string) bool {
+	requestHost := ctx.Host()
+	if netutil.IsLoopbackSubdomain(requestHost) {
+		// this fixes a bug when listening on
+		// 127.0.0.1:8080 for example
+		// and have a wildcard subdomain and a route registered to root domain.
+		return false // it's not a subdomain, it's something like 127.0.0.1 probably
+	}
+	// it's a dynamic wildcard subdomain, we have just to check if ctx.subdomain is not empty
+	if subdomain == SubdomainWildcardIndicator {
+		// mydomain.com -> invalid
+		// localhost -> invalid
+		// sub.mydomain.com -> valid
+		// sub.localhost -> valid
+		serverHost := ctx.Application().ConfigurationReadOnly().GetVHost()
+		if serverHost == requestHost {
+			return false // it's not a subdomain, it's a full domain (with .com...)
+		}
+
+		dotIdx := strings.IndexByte(requestHost, '.')
+		slashIdx := strings.IndexByte(requestHost, '/')
+		// if "." was found anywhere but not at the first path segment (host).
+		// any subdomain is valid.
+		return dotIdx > 0 && (slashIdx == -1 || slashIdx > dotIdx)
+	}
+
+	return strings.HasPrefix(requestHost, subdomain) // subdomain contains the dot.
 }
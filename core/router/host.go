@@ -0,0 +1,88 @@
+package router
+
+import (
+	"path"
+	"strings"
+
+	"github.com/kataras/iris/context"
+)
+
+// isHostPattern returns true if the "host" is a glob pattern, i.e "*.shop.*".
+func isHostPattern(host string) bool {
+	return strings.ContainsAny(host, "*?[")
+}
+
+// matchHost reports whether the request's "hostname" matches the "host",
+// which can be a full host name, i.e "shop.com", or a glob pattern, i.e "*.shop.*".
+func matchHost(host, hostname string) bool {
+	if !isHostPattern(host) {
+		return host == hostname
+	}
+
+	ok, _ := path.Match(host, hostname)
+	return ok
+}
+
+// requestHostname returns the request's host without the port, lowercased.
+func requestHostname(ctx context.Context) string {
+	host := ctx.Host()
+	if idx := strings.LastIndexByte(host, ':'); idx != -1 && strings.IndexByte(host[idx:], ']') == -1 {
+		host = host[:idx]
+	}
+	return strings.ToLower(host)
+}
+
+// hostTrees are the trees of the routes which are bound to a host, see `Party#Host`.
+type hostTrees struct {
+	host  string
+	trees *routerTrees
+}
+
+// getHost returns the trees of the routes that are bound to the "host",
+// it creates them if missing.
+func (h *routerTrees) getHost(host string) *routerTrees {
+	if !isHostPattern(host) {
+		if t, ok := h.exactHosts[host]; ok {
+			return t
+		}
+
+		if h.exactHosts == nil {
+			h.exactHosts = make(map[string]*routerTrees)
+		}
+		t := &routerTrees{}
+		h.exactHosts[host] = t
+		return t
+	}
+
+	for _, g := range h.hostPatterns {
+		if g.host == host {
+			return g.trees
+		}
+	}
+
+	t := &routerTrees{}
+	h.hostPatterns = append(h.hostPatterns, hostTrees{host: host, trees: t})
+	return t
+}
+
+// forHost returns the trees of the routes that are bound to the request's host,
+// the full host names are resolved first, then the host patterns by registration order,
+// if none of them matches then it returns the trees of the routes which are not bound to a host.
+func (h *routerTrees) forHost(ctx context.Context) *routerTrees {
+	if len(h.exactHosts) == 0 && len(h.hostPatterns) == 0 {
+		return h
+	}
+
+	hostname := requestHostname(ctx)
+	if t, ok := h.exactHosts[hostname]; ok {
+		return t
+	}
+
+	for _, g := range h.hostPatterns {
+		if matchHost(g.host, hostname) {
+			return g.trees
+		}
+	}
+
+	return h
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/kataras/iris/core/errors"
 	"github.com/kataras/iris/core/router/macro"
 	"github.com/kataras/iris/core/router/macro/interpreter/ast"
+	"github.com/kataras/iris/core/router/node"
 )
 
 // defaultMacros returns a new macro map which
@@ -203,6 +205,52 @@ func convertTmplToNodePath(tmpl *macro.Template) (string, error) {
 	return routePath, nil
 }
 
+// paramNeedsEvaluation returns false for params like: {name:string} or {name} or {anything:path}
+// without any functions used inside these params, they accept anything,
+// so no performance cost if macro is not really used.
+func paramNeedsEvaluation(p macro.TemplateParam) bool {
+	if len(p.Funcs) > 0 {
+		return true
+	}
+
+	return !(p.Type == ast.ParamTypeUnExpected || p.Type == ast.ParamTypeString || p.Type == ast.ParamTypePath)
+}
+
+// paramFallsThrough returns true if a param is evaluated by the router's tree,
+// if its value is not valid then the router continues to the next route that matches the path.
+// Params with an explicit error code (i.e {id:int else 400}) are evaluated by the macro handler instead,
+// which fires that error code.
+func paramFallsThrough(p macro.TemplateParam) bool {
+	return p.ErrCode == http.StatusNotFound
+}
+
+// convertTmplToNodeEvaluators returns the path parameters' evaluators
+// that the router's tree should use to select the correct route, by params order.
+//
+// note: returns nil if not needed.
+func convertTmplToNodeEvaluators(tmpl *macro.Template) []node.ParamEvaluator {
+	var (
+		evaluators        []node.ParamEvaluator
+		needNodeEvaluator bool
+	)
+
+	for _, p := range tmpl.Params {
+		if !paramNeedsEvaluation(p) || !paramFallsThrough(p) {
+			evaluators = append(evaluators, nil)
+			continue
+		}
+
+		needNodeEvaluator = true
+		evaluators = append(evaluators, p.Eval)
+	}
+
+	if !needNodeEvaluator {
+		return nil
+	}
+
+	return evaluators
+}
+
 // note: returns nil if not needed, the caller(router) should be check for that before adding that on route's Middleware
 func convertTmplToHandler(tmpl *macro.Template) context.Handler {
 
@@ -211,9 +259,10 @@ func convertTmplToHandler(tmpl *macro.Template) context.Handler {
 	// check if we have params like: {name:string} or {name} or {anything:path} without else keyword or any functions used inside these params.
 	// 1. if we don't have, then we don't need to add a handler before the main route's handler (as I said, no performance if macro is not really used)
 	// 2. if we don't have any named params then we don't need a handler too.
+	// 3. if the params are evaluated by the router's tree then we don't need a handler too.
+	// 4. if the params' values should be converted (custom param types with a converter) then we need a handler.
 	for _, p := range tmpl.Params {
-		if len(p.Funcs) == 0 && (p.Type == ast.ParamTypeUnExpected || p.Type == ast.ParamTypeString || p.Type == ast.ParamTypePath) && p.ErrCode == http.StatusNotFound {
-		} else {
+		if (paramNeedsEvaluation(p) && !paramFallsThrough(p)) || p.Converter != nil {
 			// println("we need handler for: " + tmpl.Src)
 			needMacroHandler = true
 		}
@@ -228,22 +277,22 @@ func convertTmplToHandler(tmpl *macro.Template) context.Handler {
 		return func(ctx context.Context) {
 			for _, p := range tmpl.Params {
 				paramValue := ctx.Params().Get(p.Name)
-				// first, check for type evaluator
-				if !p.TypeEvaluator(paramValue) {
+				// if falls through then it's already evaluated by the router.
+				if !paramFallsThrough(p) && !p.Eval(paramValue) {
 					ctx.StatusCode(p.ErrCode)
 					ctx.StopExecution()
 					return
 				}
 
-				// then check for all of its functions
-				for _, evalFunc := range p.Funcs {
-					if !evalFunc(paramValue) {
+				if p.Converter != nil {
+					v, err := p.Converter(paramValue)
+					if err != nil {
 						ctx.StatusCode(p.ErrCode)
 						ctx.StopExecution()
 						return
 					}
+					ctx.Params().SetValue(p.Name, v)
 				}
-
 			}
 			// if all passed, just continue
 			ctx.Next()
@@ -251,3 +300,41 @@ func convertTmplToHandler(tmpl *macro.Template) context.Handler {
 	}(*tmpl)
 
 }
+
+// paramsSignature returns a key which describes the types, the functions
+// and the error codes of the template's params, without their names,
+// two routes with the same method, subdomain, path and params signature are ambiguous.
+func paramsSignature(tmpl *macro.Template) string {
+	var parts []string
+	for _, p := range tmpl.Params {
+		// {id:int min(1) else 400} -> int min(1) else 400
+		src := strings.TrimSuffix(strings.TrimPrefix(p.Src, "{"+p.Name), "}")
+		if len(src) > 0 && src[0] == ':' {
+			src = src[1:]
+			if idx := strings.IndexByte(src, ' '); idx != -1 {
+				src = src[idx:]
+			} else {
+				src = ""
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%d%s", p.Type, strings.Join(strings.Fields(src), " ")))
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// sameParamNames reports whether the "a" and the "b" templates
+// have the same path parameters' names, by the same order.
+func sameParamNames(a, b *macro.Template) bool {
+	if len(a.Params) != len(b.Params) {
+		return false
+	}
+
+	for i := range a.Params {
+		if a.Params[i].Name != b.Params[i].Name {
+			return false
+		}
+	}
+
+	return true
+}
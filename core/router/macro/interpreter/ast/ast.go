@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
 )
 
 // ParamType is a specific uint8 type
@@ -93,9 +94,40 @@ func (pt ParamType) Kind() reflect.Kind {
 	case ParamTypeBoolean:
 		return reflect.Bool
 	}
+
+	// the custom param types' kind depends on their converter,
+	// which is registered per macro map.
 	return reflect.Invalid // 0
 }
 
+// String returns the identifier of this param type, i.e "int".
+// Returns an empty string for the ParamTypeUnExpected.
+func (pt ParamType) String() string {
+	paramTypesMu.RLock()
+	defer paramTypesMu.RUnlock()
+
+	for ident, typ := range paramTypes {
+		if typ == pt {
+			return ident
+		}
+	}
+	return ""
+}
+
+// IsCustom returns true if this param type
+// is not one of the builtin param types
+// but it was registered through the `RegisterParamType`.
+func (pt ParamType) IsCustom() bool {
+	if pt <= ParamTypePath {
+		return false
+	}
+
+	paramTypesMu.RLock()
+	n := customParamTypes
+	paramTypesMu.RUnlock()
+	return int(pt-ParamTypePath) <= n
+}
+
 // Assignable returns true if the "k" standard type
 // is assignabled to this ParamType.
 func (pt ParamType) Assignable(k reflect.Kind) bool {
@@ -117,6 +149,44 @@ var paramTypes = map[string]ParamType{
 
 }
 
+var (
+	// the number of the param types registered by the `RegisterParamType`,
+	// their ParamType is their order + ParamTypePath.
+	customParamTypes int
+	// paramTypesMu guards the paramTypes and the customParamTypes,
+	// the custom param types can be registered while other applications parse their routes.
+	paramTypesMu sync.RWMutex
+)
+
+// RegisterParamType registers a custom parameter type by its "ident", i.e "uuid",
+// so the parser can recognise it, i.e /users/{id:uuid}.
+//
+// The identifiers are shared between the applications, so the parser can resolve them,
+// but their evaluators and converters are registered per macro map,
+// a route's path can use a custom param type only if its macro map has registered it.
+//
+// It returns the new ParamType or the existing one if the "ident" is already registered,
+// it returns ParamTypeUnExpected if no more param types can be registered.
+//
+// It's being used by the `macro.Map#Register`, end-developers should use that instead.
+func RegisterParamType(ident string) ParamType {
+	paramTypesMu.Lock()
+	defer paramTypesMu.Unlock()
+
+	if pt, ok := paramTypes[ident]; ok {
+		return pt
+	}
+
+	if customParamTypes >= int(^ParamType(0)-ParamTypePath) {
+		return ParamTypeUnExpected
+	}
+
+	customParamTypes++
+	pt := ParamTypePath + ParamType(customParamTypes)
+	paramTypes[ident] = pt
+	return pt
+}
+
 // LookupParamType accepts the string
 // representation of a parameter type.
 // Available:
@@ -126,7 +196,11 @@ var paramTypes = map[string]ParamType{
 // "alphabetical"
 // "file"
 // "path"
+// and any custom param type registered through the `RegisterParamType`.
 func LookupParamType(ident string) ParamType {
+	paramTypesMu.RLock()
+	defer paramTypesMu.RUnlock()
+
 	if typ, ok := paramTypes[ident]; ok {
 		return typ
 	}
@@ -8,6 +8,7 @@ import (
 	"unicode"
 
 	"github.com/kataras/iris/core/router/macro/interpreter/ast"
+	"github.com/kataras/iris/core/router/macro/interpreter/token"
 )
 
 // EvaluatorFunc is the signature for both param types and param funcs.
@@ -142,8 +143,18 @@ type (
 	Macro struct {
 		Evaluator EvaluatorFunc
 		funcs     []ParamFunc
+		// converter is not nil for custom types
+		// which their values should be converted, see `Map#Register`.
+		converter ConverterFunc
+		// convertedType is the type of the converter's values, i.e uuid.UUID.
+		convertedType reflect.Type
 	}
 
+	// ConverterFunc is the signature of a param type's converter,
+	// it accepts the param's value as string, after its evaluation,
+	// and returns its typed value, i.e a uuid.UUID.
+	ConverterFunc func(paramValue string) (interface{}, error)
+
 	// ParamEvaluatorBuilder is a func
 	// which accepts a param function's arguments (values)
 	// and returns an EvaluatorFunc, its job
@@ -195,6 +206,12 @@ func (m *Macro) registerFunc(funcName string, fullFn ParamEvaluatorBuilder) {
 	})
 }
 
+// Converter returns the param type's converter,
+// it's nil if the param's values are not converted to another type.
+func (m *Macro) Converter() ConverterFunc {
+	return m.converter
+}
+
 func (m *Macro) getFunc(funcName string) ParamEvaluatorBuilder {
 	for _, fn := range m.funcs {
 		if fn.Name == funcName {
@@ -209,7 +226,8 @@ func (m *Macro) getFunc(funcName string) ParamEvaluatorBuilder {
 
 // Map contains the default macros mapped to their types.
 // This is the manager which is used by the caller to register custom
-// parameter functions per param-type (String, Int, Long, Boolean, Alphabetical, File, Path).
+// parameter functions per param-type (String, Int, Long, Boolean, Alphabetical, File, Path)
+// and custom param types through its `Register`.
 type Map struct {
 	// string type
 	// anything
@@ -240,6 +258,9 @@ type Map struct {
 	// path type
 	// anything, should be the last part
 	Path *Macro
+
+	// custom param types, registered by the `Register`.
+	custom map[ast.ParamType]*Macro
 }
 
 // NewMap returns a new macro Map with default
@@ -287,6 +308,104 @@ func (m *Map) Lookup(typ ast.ParamType) *Macro {
 	case ast.ParamTypePath:
 		return m.Path
 	default:
+		if custom, ok := m.custom[typ]; ok {
+			return custom
+		}
 		return m.String
 	}
 }
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// the convertConverterFunc return value is generating at boot time.
+// convertConverterFunc converts an interface to a valid ConverterFunc
+// and returns the type of the converted values.
+func convertConverterFunc(fn interface{}) (ConverterFunc, reflect.Type) {
+	if fn == nil {
+		return nil, nil
+	}
+
+	typFn := reflect.TypeOf(fn)
+	// should be a func(string) (T, error).
+	if typFn.Kind() != reflect.Func ||
+		typFn.NumIn() != 1 || typFn.In(0).Kind() != reflect.String ||
+		typFn.NumOut() != 2 || typFn.Out(1) != errorType {
+		return nil, nil
+	}
+
+	valFn := reflect.ValueOf(fn)
+	return func(paramValue string) (interface{}, error) {
+		out := valFn.Call([]reflect.Value{reflect.ValueOf(paramValue)})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		return out[0].Interface(), nil
+	}, typFn.Out(0)
+}
+
+// Register registers a new named parameter type, i.e "uuid",
+// so it can be used at the routes' paths, i.e /users/{id:uuid}.
+// Register should be called before the routes that are using that param type are registered.
+//
+// The "evaluator" is the param type's evaluator, it validates the param's value.
+//
+// The "converter" is optional (can be nil), it should be a func(paramValue string) (T, error)
+// which converts the, already validated, param's value to a T, i.e uuid.FromString.
+// The converted value can be retrieved by the `ctx.Params().GetValue(paramName)`
+// and the controllers' method functions can accept it as input argument, i.e GetBy(id uuid.UUID).
+//
+// Returns the new Macro, param functions can be registered to that param type through its `RegisterFunc`.
+//
+// It panics if the "ident" is not a valid identifier or it's a builtin param type or the "converter" is not valid.
+func (m *Map) Register(ident string, evaluator EvaluatorFunc, converter interface{}) *Macro {
+	if !goodParamFuncName(ident) || token.LookupIdent(ident) != token.IDENT {
+		panic(fmt.Sprintf("macro: invalid param type identifier: %q", ident))
+	}
+
+	if typ := ast.LookupParamType(ident); typ != ast.ParamTypeUnExpected && !typ.IsCustom() {
+		panic(fmt.Sprintf("macro: param type %q is a builtin param type", ident))
+	}
+
+	convFn, convTyp := convertConverterFunc(converter)
+	if converter != nil && convFn == nil {
+		panic(fmt.Sprintf("macro: converter of the param type %q should be a func(string) (T, error)", ident))
+	}
+
+	typ := ast.RegisterParamType(ident)
+	if typ == ast.ParamTypeUnExpected {
+		panic(fmt.Sprintf("macro: can not register the param type %q, too many param types", ident))
+	}
+
+	if m.custom == nil {
+		m.custom = make(map[ast.ParamType]*Macro)
+	}
+
+	macro := newMacro(evaluator)
+	macro.converter = convFn
+	macro.convertedType = convTyp
+	m.custom[typ] = macro
+	return macro
+}
+
+// LookupConvertedType returns the custom param type, registered to this Map,
+// which its values are converted to the "typ", i.e "uuid" for the uuid.UUID.
+// It's being used to map a controller's method input arguments to path parameters.
+//
+// Note that only custom param types are checked, a builtin type
+// can't be resolved by a std type because for a single reason
+// a string may be a ParamTypeString or a ParamTypeFile
+// or a ParamTypePath or ParamTypeAlphabetical.
+//
+// If more than one param types are converted to the "typ" then the first registered wins.
+// Returns ParamTypeUnExpected if not found.
+func (m *Map) LookupConvertedType(typ reflect.Type) ast.ParamType {
+	found := ast.ParamTypeUnExpected
+	for pt, macro := range m.custom {
+		if macro.convertedType != nil && macro.convertedType == typ {
+			if found == ast.ParamTypeUnExpected || pt < found {
+				found = pt
+			}
+		}
+	}
+	return found
+}
@@ -1,6 +1,8 @@
 package macro
 
 import (
+	"fmt"
+
 	"github.com/kataras/iris/core/router/macro/interpreter/ast"
 	"github.com/kataras/iris/core/router/macro/interpreter/parser"
 )
@@ -28,6 +30,9 @@ type TemplateParam struct {
 	ErrCode       int
 	TypeEvaluator EvaluatorFunc
 	Funcs         []EvaluatorFunc
+	// Converter is not nil if the param's value should be converted
+	// to a typed value, see `Map#Register`.
+	Converter ConverterFunc
 }
 
 // Parse takes a full route path and a macro map (macro map contains the macro types with their registered param functions)
@@ -43,6 +48,13 @@ func Parse(src string, macros *Map) (*Template, error) {
 	t.Src = src
 
 	for _, p := range params {
+		if p.Type.IsCustom() {
+			// custom param types are shared between parsers but not between macro maps.
+			if _, ok := macros.custom[p.Type]; !ok {
+				return nil, fmt.Errorf("unexpected parameter type: %s, it's not registered to the macros", p.Type)
+			}
+		}
+
 		funcMap := macros.Lookup(p.Type)
 		typEval := funcMap.Evaluator
 
@@ -52,6 +64,7 @@ func Parse(src string, macros *Map) (*Template, error) {
 			Name:          p.Name,
 			ErrCode:       p.ErrorCode,
 			TypeEvaluator: typEval,
+			Converter:     funcMap.converter,
 		}
 		for _, paramfn := range p.Funcs {
 			tmplFn := funcMap.getFunc(paramfn.Name)
@@ -73,3 +86,21 @@ func Parse(src string, macros *Map) (*Template, error) {
 
 	return t, nil
 }
+
+// Eval returns true if the "paramValue" is passing
+// the param type's evaluator and all of its param functions.
+func (p TemplateParam) Eval(paramValue string) bool {
+	// first, check for type evaluator
+	if !p.TypeEvaluator(paramValue) {
+		return false
+	}
+
+	// then check for all of its functions
+	for _, evalFunc := range p.Funcs {
+		if !evalFunc(paramValue) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kataras/iris/context"
+)
+
+// constrained returns true if the route is registered for an API version or for specific media types.
+func (r *Route) constrained() bool {
+	return r.Version != "" || r.hasMediaTypes()
+}
+
+// hasMediaTypes returns true if the route is constrained by the request's "Content-Type" or "Accept".
+func (r *Route) hasMediaTypes() bool {
+	return len(r.Consumes) > 0 || len(r.Produces) > 0
+}
+
+// mediaTypesSignature returns the route's media types constraints as a string,
+// routes with the same path and the same signature can't be differentiated at serve time.
+func (r *Route) mediaTypesSignature() string {
+	return strings.Join(r.Consumes, ",") + ";" + strings.Join(r.Produces, ",")
+}
+
+// consumes reports whether the route accepts a request body of the "contentType".
+func (r *Route) consumes(contentType string) bool {
+	if len(r.Consumes) == 0 {
+		return true
+	}
+
+	for _, mediaType := range r.Consumes {
+		if context.MediaTypeMatches(mediaType, contentType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasBody reports whether the request has a body, even of an unknown length.
+func hasBody(r *http.Request) bool {
+	return r.ContentLength != 0 || len(r.TransferEncoding) > 0
+}
+
+// quality returns the quality, from 0 to 1, that the route's response
+// is acceptable by the client based on the "accepted" media ranges.
+func (r *Route) quality(accepted []context.AcceptedMediaType) float64 {
+	if len(r.Produces) == 0 || len(accepted) == 0 {
+		return 1
+	}
+
+	best := 0.0
+	for _, mediaType := range r.Produces {
+		if q := context.MediaTypeQuality(accepted, mediaType); q > best {
+			best = q
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,84 @@
+package router
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	// the methods that are registered through the `RegisterMethod`.
+	customMethods []string
+	methodsMu     sync.RWMutex
+)
+
+// RegisterMethod registers custom http methods, i.e the WebDAV's "PROPFIND", "MKCOL", "LOCK" or the "QUERY",
+// globally, after that they are part of the `Methods`, therefore the `Any` routes can serve them too,
+// and a controller's method functions can be mapped to them, i.e `Propfind()` or `MkcolBy(name string)`.
+// The routes of a custom method can be registered through the `Handle` as well,
+// and they're listed at the "Allow" header of the automatic OPTIONS and 405 Method Not Allowed responses.
+//
+// The http methods are case-sensitive, so they should be given as they're sent by the clients, usually upper-case.
+// It panics if a method is not a valid http token.
+//
+// RegisterMethod should be called before the routes registration, i.e at the program's start,
+// the routes that are already registered through the `Any` are not updated.
+func RegisterMethod(methods ...string) {
+	methodsMu.Lock()
+	defer methodsMu.Unlock()
+
+	for _, method := range methods {
+		if !isValidMethod(method) {
+			panic(fmt.Sprintf("iris: invalid http method %q", method))
+		}
+
+		if method == MethodNone || method == "ANY" || method == "ALL" || hasMethod(method) {
+			continue
+		}
+
+		customMethods = append(customMethods, method)
+	}
+}
+
+// Methods returns the valid http methods, the `AllMethods` and the custom ones
+// that are registered through the `RegisterMethod`, i.e "PROPFIND".
+// It returns a new slice, it's safe for concurrent use.
+func Methods() []string {
+	methodsMu.RLock()
+	methods := make([]string, 0, len(AllMethods)+len(customMethods))
+	methods = append(append(methods, AllMethods[:]...), customMethods...)
+	methodsMu.RUnlock()
+	return methods
+}
+
+func hasMethod(method string) bool {
+	for _, m := range AllMethods {
+		if m == method {
+			return true
+		}
+	}
+	for _, m := range customMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidMethod reports whether the "method" is a valid http token, see RFC 7230, section 3.2.6.
+func isValidMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+
+	for i := 0; i < len(method); i++ {
+		c := method[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '!', c == '#', c == '$', c == '%', c == '&', c == '\'', c == '*',
+			c == '+', c == '-', c == '.', c == '^', c == '_', c == '`', c == '|', c == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
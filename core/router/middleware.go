@@ -0,0 +1,83 @@
+package router
+
+import (
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/errors"
+)
+
+// middlewareRegistry contains the named middleware, i.e "auth",
+// it's shared between the Parties and their routes, see `Party#RegisterMiddleware`.
+type middlewareRegistry struct {
+	handlers map[string]context.Handler
+	reporter *errors.Reporter
+}
+
+func newMiddlewareRegistry(reporter *errors.Reporter) *middlewareRegistry {
+	return &middlewareRegistry{
+		handlers: make(map[string]context.Handler),
+		reporter: reporter,
+	}
+}
+
+func (m *middlewareRegistry) register(name string, handler context.Handler) {
+	if name == "" || handler == nil {
+		m.reporter.Add("missing name or handler for middleware %q", name)
+		return
+	}
+
+	if _, ok := m.handlers[name]; ok {
+		m.reporter.Add("middleware %q is already registered", name)
+		return
+	}
+
+	m.handlers[name] = handler
+}
+
+// get returns the handlers of the "names" and their names, by the same order,
+// the names that are not registered are reported and skipped.
+func (m *middlewareRegistry) get(names []string) (context.Handlers, []string) {
+	var (
+		handlers context.Handlers
+		found    []string
+	)
+
+	for _, name := range names {
+		if !m.exists(name) {
+			continue
+		}
+
+		handlers = append(handlers, m.handlers[name])
+		found = append(found, name)
+	}
+
+	return handlers, found
+}
+
+// exists reports whether the "name" is registered, if not then it's reported.
+func (m *middlewareRegistry) exists(name string) bool {
+	if _, ok := m.handlers[name]; !ok {
+		m.reporter.Add("middleware %q is not registered", name)
+		return false
+	}
+	return true
+}
+
+// joinNames returns a copy of the "names1" followed by the "names2",
+// the names of the handlers are stored next to them, see `joinHandlers`.
+func joinNames(names1 []string, names2 []string) []string {
+	if len(names1)+len(names2) == 0 {
+		return nil
+	}
+
+	newNames := make([]string, 0, len(names1)+len(names2))
+	newNames = append(newNames, names1...)
+	return append(newNames, names2...)
+}
+
+// unnamed returns the names of "n" anonymous handlers.
+func unnamed(n int) []string {
+	if n == 0 {
+		return nil
+	}
+	return make([]string, n)
+}
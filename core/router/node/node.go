@@ -3,444 +3,389 @@ package node
 import (
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/kataras/iris/context"
 	"github.com/kataras/iris/core/errors"
 )
 
-// Nodes a conversion type for []*node.
-type Nodes []*node
+// Tree is a compressed radix tree of the routes' paths of a method (and subdomain),
+// the static parts of the paths are shared between the routes,
+// the path parameters and the wildcards are separate children.
+//
+// A request path is resolved by trying the static children first,
+// then the parameter child and at the end the wildcard, if a branch
+// can't serve the path then it continues to the next one (backtracking).
+type Tree struct {
+	root node
+}
 
 type node struct {
-	s                 string
-	routeName         string
-	wildcardParamName string   // name of the wildcard parameter, only one per whole Node is allowed
-	paramNames        []string // only-names
-	childrenNodes     Nodes
-	handlers          context.Handlers
-	root              bool
-	rootWildcard      bool // if it's a wildcard {path} type on root, it should allow everything but it is not conflicts with
-	// any other static or dynamic or wildcard paths if exists on other nodes.
+	// s is the static part of the path that this node consumes, empty for the root and the parameter nodes.
+	s string
+	// indices are the first bytes of the static children, by the same order, for a quick lookup.
+	indices  []byte
+	children []*node
+	// param is the child node of a named parameter, i.e ":id", its value ends at the next slash.
+	param *node
+	// wildcards are the routes which are accepting the rest of the path after this node, i.e "/static/*file",
+	// the rest should be empty or start with a slash.
+	wildcards []*leaf
+	// leaves are the routes that are registered to this exact node, if any.
+	leaves []*leaf
 }
 
-// ErrDublicate returnned from `Add` when two or more routes have the same registered path.
-var ErrDublicate = errors.New("two or more routes have the same registered path")
-
-/// TODO: clean up needed until v8.5
+// ParamEvaluator reports whether a path parameter's value
+// is accepted by a route, it's being used by the tree to
+// decide which route should serve a request path
+// when more than one routes share the same node,
+// i.e /users/{id:int} and /users/{username:alphabetical}.
+type ParamEvaluator func(paramValue string) bool
+
+// leaf is a registered route which lives inside a node,
+// a node can contain more than one leaves
+// if their path parameters are evaluated differently.
+type leaf struct {
+	routeName string
+	// the, optional, route which is kept with the route's name, see `AddRoute`.
+	route context.RouteReadOnly
+	// the names of the named parameters and the wildcard (the last one), if any,
+	// by the same order of the path's parameters values.
+	paramNames []string
+	handlers   context.Handlers
+	// evaluators are the path parameters' evaluators, including the wildcard (last) one,
+	// by the same order of the parameters' values, nil evaluator means that
+	// the parameter accepts anything.
+	evaluators []ParamEvaluator
+}
 
-// Add adds a node to the tree, returns an ErrDublicate error on failure.
-func (nodes *Nodes) Add(routeName string, path string, handlers context.Handlers) error {
-	// println("[Add] adding path: " + path)
-	// resolve params and if that node should be added as root
-	var params []string
-	var paramStart, paramEnd int
-	for {
-		paramStart = strings.IndexByte(path[paramEnd:], ':')
-		if paramStart == -1 {
-			break
-		}
-		paramStart += paramEnd
-		paramStart++
-		paramEnd = strings.IndexByte(path[paramStart:], '/')
-
-		if paramEnd == -1 {
-			params = append(params, path[paramStart:])
-			path = path[:paramStart]
-			break
+// evaluated returns true if at least one of the leaf's parameters should be evaluated.
+func (l *leaf) evaluated() bool {
+	for _, eval := range l.evaluators {
+		if eval != nil {
+			return true
 		}
-		paramEnd += paramStart
-		params = append(params, path[paramStart:paramEnd])
-		path = path[:paramStart] + path[paramEnd:]
-		paramEnd -= paramEnd - paramStart
 	}
+	return false
+}
 
-	var p []int
-	for i := 0; i < len(path); i++ {
-		idx := strings.IndexByte(path[i:], ':')
-		if idx == -1 {
-			break
+// accepts returns true if all of the "paramValues" are passing the leaf's evaluators.
+func (l *leaf) accepts(paramValues []string) bool {
+	for i, eval := range l.evaluators {
+		if eval == nil {
+			continue
 		}
-		p = append(p, idx+i)
-		i = idx + i
-	}
 
-	for _, idx := range p {
-		// print("-2 nodes.Add: path: " + path + " params len: ")
-		// println(len(params))
-		if err := nodes.add(routeName, path[:idx], nil, nil, true); err != nil {
-			return err
+		paramValue := ""
+		if i < len(paramValues) {
+			paramValue = paramValues[i]
 		}
-		// print("-1 nodes.Add: path: " + path + " params len: ")
-		// println(len(params))
-		if nidx := idx + 1; len(path) > nidx {
-			if err := nodes.add(routeName, path[:nidx], nil, nil, true); err != nil {
-				return err
-			}
-		}
-	}
 
-	// print("nodes.Add: path: " + path + " params len: ")
-	// println(len(params))
-	if err := nodes.add(routeName, path, params, handlers, true); err != nil {
-		return err
+		if !eval(paramValue) {
+			return false
+		}
 	}
 
-	// prioritize by static path remember, they were already sorted by subdomains too.
-	nodes.prioritize()
-	return nil
+	return true
 }
 
-func (nodes *Nodes) add(routeName, path string, paramNames []string, handlers context.Handlers, root bool) (err error) {
-
-	// println("[add] adding path: " + path)
+// ErrDublicate returnned from `Add` when two or more routes have the same registered path.
+var ErrDublicate = errors.New("two or more routes have the same registered path")
 
-	// wraia etsi doulevei ara
-	// na to kanw na exei to node to diko tou wildcard parameter name
-	// kai sto telos na pernei auto, me vasi to *paramname
-	// alla edw mesa 9a ginete register vasi tou last /
+// Add registers a route's path to the tree, returns an ErrDublicate error on failure.
+// The path's named parameters start with ':' and they end at the next slash, i.e "/users/:id",
+// the wildcard starts with '*' and it should be the last part of the path, i.e "/static/*file".
+//
+// The optional "evaluators" are the route's path parameters' evaluators (by order, wildcard is the last one),
+// routes that are sharing the same path can be registered as long as their parameters are evaluated differently,
+// the first route which its evaluators accept the request path's parameters values wins.
+func (t *Tree) Add(routeName string, path string, handlers context.Handlers, evaluators ...ParamEvaluator) error {
+	return t.AddRoute(routeName, nil, path, handlers, evaluators...)
+}
 
-	// set the wildcard param name to the root and its children.
-	wildcardIdx := strings.IndexByte(path, '*')
-	wildcardParamName := ""
-	if wildcardIdx > 0 && len(paramNames) == 0 { // 27 Oct comment: && len(paramNames) == 0 {
-		wildcardParamName = path[wildcardIdx+1:]
-		path = path[0:wildcardIdx-1] + "/" // replace *paramName with single slash
+// AddRoute same as `Add` but it keeps the "route" with the route's name,
+// it's returned by the `FindRoute` so the route is not resolved by its name on each request.
+func (t *Tree) AddRoute(routeName string, route context.RouteReadOnly, path string, handlers context.Handlers, evaluators ...ParamEvaluator) error {
+	if len(handlers) == 0 {
+		return nil
+	}
 
-		// if path[len(path)-1] == '/' {
-		// if root wildcard, then add it as it's and return
-		rootWildcard := path == "/"
-		if rootWildcard {
-			path += "/" // if root wildcard, then do it like "//" instead of simple "/"
-		}
+	l := &leaf{routeName: routeName, route: route, handlers: handlers, evaluators: evaluators}
+	n := &t.root
 
-		n := &node{
-			rootWildcard:      rootWildcard,
-			s:                 path,
-			routeName:         routeName,
-			wildcardParamName: wildcardParamName,
-			paramNames:        paramNames,
-			handlers:          handlers,
-			root:              root,
+	for {
+		if path == "" {
+			return addLeaf(&n.leaves, l)
 		}
-		*nodes = append(*nodes, n)
-		// println("1. nodes.Add path: " + path)
-		return
 
-	}
+		switch path[0] {
+		case ':':
+			end := strings.IndexByte(path, '/')
+			if end == -1 {
+				end = len(path)
+			}
+			l.paramNames = append(l.paramNames, path[1:end])
 
-loop:
-	for _, n := range *nodes {
-		if n.rootWildcard {
+			if n.param == nil {
+				n.param = new(node)
+			}
+			n = n.param
+			path = path[end:]
 			continue
+		case '*':
+			l.paramNames = append(l.paramNames, path[1:])
+			return addLeaf(&n.wildcards, l)
 		}
 
-		if len(n.paramNames) == 0 && n.wildcardParamName != "" {
-			continue
+		end := strings.IndexAny(path, ":*")
+		if end == -1 {
+			end = len(path)
+		} else if path[end] == '*' && end > 0 && path[end-1] == '/' {
+			// the slash before the wildcard is part of the wildcard's value,
+			// i.e "/static/*file" serves the "/static" too.
+			end--
+			path = path[:end] + path[end+1:]
 		}
 
-		minlen := len(n.s)
-		if len(path) < minlen {
-			minlen = len(path)
+		if end == 0 {
+			continue
 		}
 
-		for i := 0; i < minlen; i++ {
-			if n.s[i] == path[i] {
-				continue
-			}
-			if i == 0 {
-				continue loop
-			}
-
-			*n = node{
-				s: n.s[:i],
-				childrenNodes: Nodes{
-					{
-						s:                 n.s[i:],
-						routeName:         n.routeName,
-						wildcardParamName: n.wildcardParamName, // wildcardParamName
-						paramNames:        n.paramNames,
-						childrenNodes:     n.childrenNodes,
-						handlers:          n.handlers,
-					},
-					{
-						s:                 path[i:],
-						routeName:         routeName,
-						wildcardParamName: wildcardParamName,
-						paramNames:        paramNames,
-						handlers:          handlers,
-					},
-				},
-				root: n.root,
-			}
+		n = n.static(path[:end])
+		path = path[len(n.s):]
+	}
+}
 
-			// fmt.Printf("%#v\n", n)
-			// println("2. change n and return  " + n.s[:i] + " and " + path[i:])
-			return
+// static returns the child which its path is a prefix of the "path"
+// or a new one if none shares the same first character,
+// the existing child is splitted if it shares just a part of the "path".
+//
+// The children are splitted on characters' boundaries, so the case-insensitive lookup
+// can compare whole characters, therefore two children may share the same first byte.
+func (n *node) static(path string) *node {
+	for i, c := range n.indices {
+		if c != path[0] {
+			continue
 		}
 
-		if len(path) < len(n.s) {
-			// 	println("3. change n and return | n.s[:len(path)] = " + n.s[:len(path)-1] + " and child: " + n.s[len(path)-1:])
-
-			*n = node{
-				s:                 n.s[:len(path)],
-				routeName:         routeName,
-				wildcardParamName: wildcardParamName,
-				paramNames:        paramNames,
-				childrenNodes: Nodes{
-					{
-						s:                 n.s[len(path):],
-						routeName:         n.routeName,
-						wildcardParamName: n.wildcardParamName, // wildcardParamName
-						paramNames:        n.paramNames,
-						childrenNodes:     n.childrenNodes,
-						handlers:          n.handlers,
-					},
-				},
-				handlers: handlers,
-				root:     n.root,
-			}
+		child := n.children[i]
+		common := 0
+		for common < len(child.s) && common < len(path) && child.s[common] == path[common] {
+			common++
+		}
+		for common > 0 && common < len(child.s) && !utf8.RuneStart(child.s[common]) {
+			common--
+		}
 
-			return
+		if common == 0 {
+			continue
 		}
 
-		if len(path) > len(n.s) {
-			if n.wildcardParamName != "" {
-				n := &node{
-					s:                 path,
-					routeName:         routeName,
-					wildcardParamName: wildcardParamName,
-					paramNames:        paramNames,
-					handlers:          handlers,
-					root:              root,
-				}
-				// println("3.5. nodes.Add path: " + n.s)
-				*nodes = append(*nodes, n)
-				return
+		if common < len(child.s) {
+			// split the child, the new one keeps the common prefix
+			// and the old one continues with the rest of its path.
+			rest := *child
+			rest.s = child.s[common:]
+			*child = node{
+				s:        child.s[:common],
+				indices:  []byte{rest.s[0]},
+				children: []*node{&rest},
 			}
-
-			pathToAdd := path[len(n.s):]
-			// println("4. nodes.Add path: " + pathToAdd)
-			err = n.childrenNodes.add(routeName, pathToAdd, paramNames, handlers, false)
-			return err
 		}
 
-		if len(handlers) == 0 { // missing handlers
-			return nil
-		}
+		return child
+	}
+
+	child := &node{s: path}
+	n.indices = append(n.indices, path[0])
+	n.children = append(n.children, child)
+	return child
+}
 
-		if len(n.handlers) > 0 { // n.handlers already setted
+// addLeaf registers a route to the node's routes.
+// Returns ErrDublicate if both the new and an existing route
+// are accepting any parameter value, the evaluated routes
+// are always being tried before the not-evaluated one.
+func addLeaf(leaves *[]*leaf, l *leaf) error {
+	for _, existing := range *leaves {
+		if !existing.evaluated() && !l.evaluated() {
 			return ErrDublicate
 		}
-		n.paramNames = paramNames
-		n.handlers = handlers
-		return
 	}
 
-	// START
-	// Author's note:
-	// 27 Oct 2017; fixes s|i|l+static+p
-	//			    without breaking the current tests.
-	if wildcardIdx > 0 {
-		wildcardParamName = path[wildcardIdx+1:]
-		path = path[0:wildcardIdx-1] + "/"
-	}
-	// END
-
-	n := &node{
-		s:                 path,
-		routeName:         routeName,
-		wildcardParamName: wildcardParamName,
-		paramNames:        paramNames,
-		handlers:          handlers,
-		root:              root,
-	}
-	*nodes = append(*nodes, n)
+	*leaves = append(*leaves, l)
+	sort.SliceStable(*leaves, func(i, j int) bool {
+		return (*leaves)[i].evaluated() && !(*leaves)[j].evaluated()
+	})
 
-	// println("5. node add on path: " + path + " n.s: " + n.s + " wildcard param: " + n.wildcardParamName)
-	return
+	return nil
 }
 
-// Find resolves the path, fills its params
-// and returns the registered to the resolved node's handlers.
-func (nodes Nodes) Find(path string, params *context.RequestParams) (string, context.Handlers) {
-	n, paramValues := nodes.findChild(path, nil)
-	if n != nil {
-		//	map the params,
-		// n.params are the param names
-		if len(paramValues) > 0 {
-			// println("-----------")
-			// print("param values returned len: ")
-			// println(len(paramValues))
-			// println("first value is: " + paramValues[0])
-			// print("n.paramNames len: ")
-			// println(len(n.paramNames))
-			for i, name := range n.paramNames {
-				// println("setting param name: " + name + " = " + paramValues[i])
-				params.Set(name, paramValues[i])
-			}
-			// last is the wildcard,
-			// if paramValues are exceed from the registered param names.
-			// Note that n.wildcardParamName can be not empty but that doesn't meaning
-			// that it contains a wildcard path, so the check is required.
-			if len(paramValues) > len(n.paramNames) {
-				// println("len(paramValues) > len(n.paramNames)")
-				lastWildcardVal := paramValues[len(paramValues)-1]
-				// println("setting wildcard param name: " + n.wildcardParamName + " = " + lastWildcardVal)
-				params.Set(n.wildcardParamName, lastWildcardVal)
-			}
+// match returns the first of the "leaves"
+// which accepts the "paramValues", otherwise nil.
+func match(leaves []*leaf, paramValues []string, accept func(routeName string) bool) *leaf {
+	for _, l := range leaves {
+		if (len(l.evaluators) == 0 || l.accepts(paramValues)) && (accept == nil || accept(l.routeName)) {
+			return l
 		}
-		return n.routeName, n.handlers
 	}
+	return nil
+}
+
+// maxStackParams is the number of the parameters values that are collected
+// to a buffer on the stack, a request path with more parameters grows it to the heap.
+const maxStackParams = 8
+
+// Find resolves the path, fills its params
+// and returns the registered to the resolved node's route name and handlers.
+func (t *Tree) Find(path string, params *context.RequestParams) (string, context.Handlers) {
+	return t.FindFunc(path, params, false, nil)
+}
 
-	return "", nil
+// FindFunc same as `Find` but it compares the static parts of the path case-insensitively
+// if "caseInsensitive" is true, the parameters' values are kept as they're given by the "path".
+// The optional "accept" reports whether a route, which can serve the "path", is allowed to serve it,
+// if not then the lookup continues to the next matching route.
+func (t *Tree) FindFunc(path string, params *context.RequestParams, caseInsensitive bool, accept func(routeName string) bool) (string, context.Handlers) {
+	routeName, _, handlers := t.FindRoute(path, params, caseInsensitive, accept)
+	return routeName, handlers
 }
 
-// Exists returns true if a node with that "path" exists,
+// FindRoute same as `FindFunc` but it returns the route which is kept with the route's name too,
+// see `AddRoute`, it's nil for the routes that are registered by `Add`.
+func (t *Tree) FindRoute(path string, params *context.RequestParams, caseInsensitive bool, accept func(routeName string) bool) (string, context.RouteReadOnly, context.Handlers) {
+	var values [maxStackParams]string
+	l, paramValues := t.root.find(path, values[:0], caseInsensitive, accept)
+	if l == nil {
+		return "", nil, nil
+	}
+
+	for i, name := range l.paramNames {
+		params.Set(name, paramValues[i])
+	}
+	return l.routeName, l.route, l.handlers
+}
+
+// Exists returns the route's name and true if a route can serve the "path",
 // otherise false.
 //
-// We don't care about parameters here.
-func (nodes Nodes) Exists(path string) bool {
-	n, _ := nodes.findChild(path, nil)
-	return n != nil && len(n.handlers) > 0
+// We don't care about parameters here,
+// except of their evaluation, the parameters are not filled.
+func (t *Tree) Exists(path string) (string, bool) {
+	var values [maxStackParams]string
+	l, _ := t.root.find(path, values[:0], false, nil)
+	if l == nil {
+		return "", false
+	}
+	return l.routeName, true
 }
 
-func (nodes Nodes) findChild(path string, params []string) (*node, []string) {
-
-	for _, n := range nodes {
-		if n.s == ":" {
-			paramEnd := strings.IndexByte(path, '/')
-			if paramEnd == -1 {
-				if len(n.handlers) == 0 {
-					return nil, nil
+// find returns the route which can serve the "path", the node's static part is already consumed,
+// and the path's parameters values, which are appended to the "paramValues".
+func (n *node) find(path string, paramValues []string, fold bool, accept func(string) bool) (*leaf, []string) {
+	if path == "" {
+		if l := match(n.leaves, paramValues, accept); l != nil {
+			return l, paramValues
+		}
+	} else {
+		// static.
+		for i, c := range n.indices {
+			child := n.children[i]
+			if fold {
+				if end, ok := hasPrefixFold(path, child.s); ok {
+					if l, values := child.find(path[end:], paramValues, fold, accept); l != nil {
+						return l, values
+					}
 				}
-				return n, append(params, path)
+				continue
 			}
-			return n.childrenNodes.findChild(path[paramEnd:], append(params, path[:paramEnd]))
-		}
 
-		// println("n.s: " + n.s)
-		// print("n.childrenNodes len: ")
-		// println(len(n.childrenNodes))
-		// print("n.root: ")
-		// println(n.root)
-
-		// by runtime check of:,
-		// if n.s == "//" && n.root && n.wildcardParamName != "" {
-		// but this will slow down, so we have a static field on the node itself:
-		if n.rootWildcard {
-			// println("return from n.rootWildcard")
-			// single root wildcard
-			if len(path) < 2 {
-				// do not remove that, it seems useless but it's not,
-				// we had an error while production, this fixes that.
-				path = "/" + path
-			}
-			return n, append(params, path[1:])
-		}
-
-		// second conditional may be unnecessary
-		// because of the n.rootWildcard before, but do it.
-		if n.wildcardParamName != "" && len(path) > 2 {
-			// println("n has wildcard n.s: " + n.s + " on path: " + path)
-			// n.s = static/, path = static
-
-			// 	println(n.s + " vs path: " + path)
-
-			// we could have /other/ as n.s so
-			// we must do this check, remember:
-			// now wildcards live on their own nodes
-			if len(path) == len(n.s)-1 {
-				// then it's like:
-				// path = /other2
-				// ns = /other2/
-				if path == n.s[0:len(n.s)-1] {
-					return n, params
+			if c == path[0] && strings.HasPrefix(path, child.s) {
+				if len(path) == len(child.s) && len(child.leaves) > 0 {
+					// the child consumes the rest of the path, i.e a static route.
+					if l := match(child.leaves, paramValues, accept); l != nil {
+						return l, paramValues
+					}
 				}
-			}
 
-			// othwerwise path = /other2/dsadas
-			// ns= /other2/
-			if strings.HasPrefix(path, n.s) {
-				if len(path) > len(n.s)+1 {
-					return n, append(params, path[len(n.s):]) // without slash
+				if l, values := child.find(path[len(child.s):], paramValues, fold, accept); l != nil {
+					return l, values
 				}
 			}
-
 		}
 
-		if !strings.HasPrefix(path, n.s) {
-			// fmt.Printf("---here root: %v, n.s: "+n.s+" and path: "+path+" is dynamic: %v , wildcardParamName: %s, children len: %v \n", n.root, n.isDynamic(), n.wildcardParamName, len(n.childrenNodes))
-			// println(path + " n.s: " + n.s + " continue...")
-			continue
-		}
+		// named parameter.
+		if n.param != nil && path[0] != '/' {
+			end := strings.IndexByte(path, '/')
+			if end == -1 {
+				end = len(path)
+			}
 
-		if len(path) == len(n.s) {
-			if len(n.handlers) == 0 {
-				return nil, nil
+			if l, values := n.param.find(path[end:], append(paramValues, path[:end]), fold, accept); l != nil {
+				return l, values
 			}
-			return n, params
 		}
+	}
 
-		child, childParamNames := n.childrenNodes.findChild(path[len(n.s):], params)
+	// wildcard, the rest of the path without its first slash.
+	if len(n.wildcards) > 0 && (path == "" || path[0] == '/') {
+		value := path
+		if value != "" {
+			value = value[1:]
+		}
 
-		// print("childParamNames len: ")
-		// println(len(childParamNames))
+		values := append(paramValues, value)
+		if l := match(n.wildcards, values, accept); l != nil {
+			return l, values
+		}
+	}
 
-		// if len(childParamNames) > 0 {
-		// 	println("childParamsNames[0] = " + childParamNames[0])
-		// }
+	return nil, paramValues
+}
 
-		if child == nil || len(child.handlers) == 0 {
-			if n.s[len(n.s)-1] == '/' && !(n.root && (n.s == "/" || len(n.childrenNodes) > 0)) {
-				if len(n.handlers) == 0 {
-					return nil, nil
-				}
+// hasPrefixFold reports whether the "path" starts with the "prefix" under Unicode case-folding
+// and returns the length of the path's part that matched, it may differ from the prefix's one,
+// i.e the Kelvin sign 'K' matches the 'k'.
+func hasPrefixFold(path, prefix string) (int, bool) {
+	i := 0
+	for j := 0; j < len(prefix); {
+		if i >= len(path) {
+			return 0, false
+		}
 
-				// println("if child == nil.... | n.s = " + n.s)
-				// print("n.paramNames len: ")
-				// println(n.paramNames)
-				// print("n.wildcardParamName is: ")
-				// println(n.wildcardParamName)
-				// print("return n, append(params, path[len(n.s) | params: ")
-				// println(path[len(n.s):])
-				return n, append(params, path[len(n.s):])
+		r, size := utf8.DecodeRuneInString(path[i:])
+		pr, psize := utf8.DecodeRuneInString(prefix[j:])
+		if (r == utf8.RuneError && size <= 1) || (pr == utf8.RuneError && psize <= 1) {
+			// invalid or incomplete characters are compared by their bytes.
+			if path[i] != prefix[j] {
+				return 0, false
 			}
-
+			i++
+			j++
 			continue
 		}
 
-		return child, childParamNames
+		if !equalFoldRune(r, pr) {
+			return 0, false
+		}
+		i += size
+		j += psize
 	}
-	return nil, nil
-}
 
-// childLen returns all the children's and their children's length.
-func (n *node) childLen() (i int) {
-	for _, n := range n.childrenNodes {
-		i++
-		i += n.childLen()
-	}
-	return
+	return i, true
 }
 
-func (n *node) isDynamic() bool {
-	return n.s == ":" || n.wildcardParamName != "" || n.rootWildcard
-}
+// equalFoldRune reports whether "a" and "b" are the same character under Unicode case-folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
 
-// prioritize sets the static paths first.
-func (nodes Nodes) prioritize() {
-	sort.Slice(nodes, func(i, j int) bool {
-		if nodes[i].isDynamic() {
-			return false
-		}
-		if nodes[j].isDynamic() {
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
 			return true
 		}
-
-		return nodes[i].childLen() > nodes[j].childLen()
-	})
-
-	for _, n := range nodes {
-		n.childrenNodes.prioritize()
 	}
+	return false
 }
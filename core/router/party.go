@@ -1,6 +1,8 @@
 package router
 
 import (
+	"time"
+
 	"github.com/kataras/iris/context"
 	"github.com/kataras/iris/mvc/activator"
 )
@@ -12,6 +14,10 @@ import (
 // Party could also be named as 'Join' or 'Node' or 'Group' , Party chosen because it is fun.
 //
 // Look the "APIBuilder" for its implementation.
+//
+// The routes can be removed or replaced while serving, see `RemoveRoute` and `ReplaceRoute`,
+// but not concurrently with the Party's setup methods, i.e `Use` and `Done`,
+// the router should be refreshed, through the `RefreshRouter`, in order to serve the changes.
 type Party interface {
 	// Party groups routes which may have the same prefix and share same handlers,
 	// returns that new rich subrouter.
@@ -40,15 +46,90 @@ type Party interface {
 	//
 	// If called from a child party then the subdomain will be prepended to the path instead of appended.
 	// So if app.Subdomain("admin.").Subdomain("panel.") then the result is: "panel.admin.".
+	//
+	// The subdomain's labels can be parameters, with the same syntax as the path's parameters,
+	// i.e "{tenant:alphabetical}." or "{region}.{tenant}.", their values are stored to the `Context#Params`.
 	Subdomain(subdomain string, middleware ...context.Handler) Party
 
+	// Host returns a new party which is responsible to register routes to
+	// a full host name, i.e "shop.com", or to a host pattern, i.e "*.shop.*".
+	//
+	// The routes of a host are resolved before the path routing and before the routes that are not bound to a host,
+	// the full host names are resolved first, then the host patterns by registration order.
+	// The party can have its own middleware and error code handlers, as any other party.
+	Host(host string, middleware ...context.Handler) Party
+
 	// Use appends Handler(s) to the current Party's routes and child routes.
 	// If the current Party is the root, then it registers the middleware to all child Parties' routes too.
 	Use(middleware ...context.Handler)
 
+	// RegisterMiddleware registers a "handler" by its "name", i.e "auth",
+	// the named middleware can be used by the `UseNamed`, `DoneNamed` and `Route#UseNamed`
+	// and a route can opt out of them through the `Route#Except`.
+	// The names are shared between all of the Parties, a name can't be registered twice.
+	RegisterMiddleware(name string, handler context.Handler)
+	// UseNamed same as `Use` but it appends the middleware that are registered by their "names".
+	UseNamed(names ...string)
+
 	// Done appends to the very end, Handler(s) to the current Party's routes and child routes
 	// The difference from .Use is that this/or these Handler(s) are being always running last.
 	Done(handlers ...context.Handler)
+	// DoneNamed same as `Done` but it appends the middleware that are registered by their "names".
+	DoneNamed(names ...string)
+
+	// OnErrorCode registers an error http status code
+	// based on the "statusCode" >= 400.
+	//
+	// The handlers are scoped to this Party's subdomain and path,
+	// if a Party has no handler for a status code then its parent's handler is fired instead.
+	OnErrorCode(statusCode int, handlers ...context.Handler)
+	// OnAnyErrorCode registers a handler which called when error status code written.
+	// Same as `OnErrorCode` but registers all http error codes.
+	OnAnyErrorCode(handlers ...context.Handler)
+
+	// TrailingSlash sets the policy for the requests with a trailing slash, i.e "/orders/",
+	// to this Party's routes and its children's routes that are registered after this call.
+	//
+	// The policy can be one of the:
+	// `TrailingSlashRedirect`, `TrailingSlashMatch` and `TrailingSlashStrict`,
+	// the `TrailingSlashDefault` follows the `Configuration#DisablePathCorrection`.
+	//
+	// Returns this Party, to continue as normal.
+	TrailingSlash(policy TrailingSlashPolicy) Party
+	// PathMatching sets how the request paths that are not matching exactly a route's path, i.e "/Products/ABC",
+	// are matched to this Party's routes and its children's routes that are registered after this call.
+	//
+	// The "matching" can be a combination of the:
+	// `PathMatchCaseInsensitive`, `PathMatchNormalize` and `PathMatchRedirect`,
+	// it replaces the configuration's ones, the `PathMatchExact` matches the registered path only
+	// and the `PathMatchDefault` follows the configuration.
+	//
+	// Returns this Party, to continue as normal.
+	PathMatching(matching PathMatching) Party
+
+	// Version returns a new Party, with the same path, which registers its routes for the API "version", i.e "1.2.0".
+	//
+	// Routes that are sharing the same method and path are selected at serve time
+	// based on the requested version range, i.e "1.2.0", "1", "^1.2" or ">= 1.0, < 3",
+	// the latest version which satisfies the range is served.
+	// The range is read from the "Accept-Version" header or from the "version" parameter of
+	// the "Accept" header's media types, i.e "application/vnd.api+json; version=1".
+	//
+	// The resolved version is available through the `Context#GetVersion`.
+	Version(version string, middleware ...context.Handler) Party
+	// DefaultVersion sets the version range, i.e "1" or "1.2.0", that is served
+	// when the request doesn't ask for a specific version,
+	// to this Party's routes and its children's routes that are registered after this call.
+	// Defaults to the latest version.
+	//
+	// Returns this Party, to continue as normal.
+	DefaultVersion(version string) Party
+	// Deprecate marks this Party's routes and its children's routes that are registered after this call
+	// as deprecated since the "date", the "date" can be zero if it's unknown.
+	// The versioned routes send a "Deprecation" response header.
+	//
+	// Returns this Party, to continue as normal.
+	Deprecate(date time.Time) Party
 
 	// Handle registers a route to the server's router.
 	// if empty method is passed then handler(s) are being registered to all methods, same as .Any.
@@ -72,6 +153,25 @@ type Party interface {
 	// in order to handle more than one paths for the same controller instance.
 	HandleMany(method string, relativePath string, handlers ...context.Handler) []*Route
 
+	// RemoveRoute removes a registered route based on its name, i.e "GET/users/{id:int}" or a custom one.
+	// Returns true if the route was found and removed.
+	RemoveRoute(routeName string) bool
+	// RemoveRouteByPath removes a registered route based on its http method and its path,
+	// the "relativePath" is relative to this Party's one, as the `Handle`'s, i.e "/users/{id:int}",
+	// only a route of this Party's host and version, see `Host` and `Version`, can be removed.
+	// Returns true if the route was found and removed.
+	RemoveRouteByPath(method string, relativePath string) bool
+	// RemoveRoutes removes the "routes", by their identity and not by their names,
+	// i.e the routes that are returned by the `Handle`.
+	// Returns the number of the routes that were found and removed.
+	RemoveRoutes(routes ...*Route) int
+	// ReplaceRoute registers a route as the `Handle` does but if a route
+	// with the same http method and path is already registered, to this Party's host and version, then it replaces that route,
+	// the new route takes its name, its metadata and its position.
+	//
+	// Returns the new *Route, app will throw any errors later on.
+	ReplaceRoute(method string, relativePath string, handlers ...context.Handler) *Route
+
 	// None registers an "offline" route
 	// see context.ExecRoute(routeName) and
 	// party.Routes().Online(handleResultregistry.*Route, "GET") and
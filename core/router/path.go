@@ -237,6 +237,8 @@ func toStringSlice(args []interface{}) (argsString []string) {
 // developers can just concat the subdomain, (host can be auto-retrieve by browser using the Path).
 
 // URL same as Path but returns the full uri, i.e https://mysubdomain.mydomain.com/hello/iris
+//
+// If the route's subdomain has parameters, i.e "{tenant}.", then the first "paramValues" are the subdomain's ones.
 func (ps *RoutePathReverser) URL(routeName string, paramValues ...interface{}) (url string) {
 	if ps.vhost == "" || ps.vscheme == "" {
 		return "not supported"
@@ -257,6 +259,11 @@ func (ps *RoutePathReverser) URL(routeName string, paramValues ...interface{}) (
 		subdomain := args[0]
 		host = subdomain + "." + host
 		args = args[1:] // remove the subdomain part for the arguments,
+	} else if r.subdomainTmpl != nil {
+		// the first arguments are the subdomain's parameters, i.e {tenant}.
+		var subdomain string
+		subdomain, args = r.subdomainTmpl.resolve(args)
+		host = subdomain + "." + host
 	}
 
 	if parsedPath := r.ResolvePath(args...); parsedPath != "" {
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"strings"
+
+	"github.com/kataras/iris/context"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// PathMatching describes how the request paths that are not matching exactly
+// a registered route's path are matched, i.e "/Products/ABC" for a route registered as "/products/{id}".
+// The values can be combined, i.e `PathMatchCaseInsensitive | PathMatchNormalize`.
+//
+// It's set per Party, see `Party#PathMatching`.
+type PathMatching uint8
+
+// PathMatchDefault follows the `Configuration#EnableCaseInsensitivePaths`,
+// `Configuration#EnablePathNormalization` and `Configuration#EnableCanonicalPathRedirect`.
+const PathMatchDefault PathMatching = 0
+
+const (
+	// PathMatchCaseInsensitive matches the static parts of the path case-insensitively,
+	// the path parameters' values are kept in their original case.
+	PathMatchCaseInsensitive PathMatching = 1 << iota
+	// PathMatchNormalize normalizes the percent-decoded request path
+	// to the Unicode's NFC form before it's matched.
+	PathMatchNormalize
+	// PathMatchRedirect redirects the client to the route's registered form of the path,
+	// with 301 for GET and HEAD and 308 for the rest of the methods,
+	// instead of serving the request directly.
+	PathMatchRedirect
+	// PathMatchExact matches the registered path only, it ignores the configuration.
+	PathMatchExact
+)
+
+// Has reports whether the "other" is part of the "m".
+func (m PathMatching) Has(other PathMatching) bool {
+	return m&other == other
+}
+
+// pathMatching returns the configuration's path matching.
+func pathMatching(config context.ConfigurationReadOnly) (m PathMatching) {
+	if config.GetEnableCaseInsensitivePaths() {
+		m |= PathMatchCaseInsensitive
+	}
+	if config.GetEnablePathNormalization() {
+		m |= PathMatchNormalize
+	}
+	if config.GetEnableCanonicalPathRedirect() {
+		m |= PathMatchRedirect
+	}
+	return
+}
+
+// findLoose resolves the "path" that is not matching exactly a registered route's path
+// by the path matching of the route that can serve it, see `PathMatching`.
+// Returns the "read-only" route and handlers, and whether the client should be redirected to the route's registered path.
+func (h *routerTrees) findLoose(ctx context.Context, method, path string, defaults PathMatching) (context.RouteReadOnly, context.Handlers, bool) {
+	if defaults == PathMatchDefault && h.pathMatching == nil {
+		return nil, nil, false
+	}
+
+	matching := func(routeName string) PathMatching {
+		if m, ok := h.pathMatching[routeName]; ok {
+			return m
+		}
+		return defaults
+	}
+
+	normalized := path
+	if (defaults | h.pathMatchingAny).Has(PathMatchNormalize) && !norm.NFC.IsNormalString(path) {
+		normalized = norm.NFC.String(path)
+
+		routeName, route, handlers := h.findFunc(ctx, method, normalized, false, func(routeName string) bool {
+			return matching(routeName).Has(PathMatchNormalize)
+		})
+		if len(handlers) > 0 {
+			return route, handlers, matching(routeName).Has(PathMatchRedirect)
+		}
+	}
+
+	if !(defaults | h.pathMatchingAny).Has(PathMatchCaseInsensitive) {
+		return nil, nil, false
+	}
+
+	routeName, route, handlers := h.findFunc(ctx, method, normalized, true, func(routeName string) bool {
+		m := matching(routeName)
+		return m.Has(PathMatchCaseInsensitive) && (normalized == path || m.Has(PathMatchNormalize))
+	})
+	if len(handlers) > 0 {
+		return route, handlers, matching(routeName).Has(PathMatchRedirect)
+	}
+
+	return nil, nil, false
+}
+
+// canonicalPath returns the registered form of the current route's path,
+// filled with the request's path parameters' values.
+func canonicalPath(ctx context.Context) string {
+	rd, ok := ctx.GetCurrentRoute().(routeReadOnlyWrapper)
+	if !ok {
+		return ""
+	}
+
+	path := rd.FormattedPath
+	for _, p := range rd.tmpl.Params {
+		path = strings.Replace(path, "%v", ctx.Params().Get(p.Name), 1)
+	}
+
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		// an empty wildcard's value, i.e "/static/{file:path}" for the "/STATIC".
+		path = path[:len(path)-1]
+	}
+	return path
+}
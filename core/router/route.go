@@ -3,9 +3,12 @@ package router
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/memstore"
 	"github.com/kataras/iris/core/router/macro"
+	"github.com/kataras/iris/mvc/activator/methodfunc"
 )
 
 // Route contains the information about a registered Route.
@@ -14,9 +17,13 @@ import (
 type Route struct {
 	Name      string          // "userRoute"
 	Method    string          // "GET"
-	Subdomain string          // "admin."
+	Host      string          // "shop.com" or "*.shop.*", see `Party#Host`.
+	Subdomain string          // "admin." or "{tenant:alphabetical}."
 	tmpl      *macro.Template // Tmpl().Src: "/api/user/{id:int}"
 	Path      string          // "/api/user/:id"
+	// the parsed subdomain, nil if the subdomain has no parameters.
+	subdomainTmpl *subdomainTemplate
+
 	// temp storage, they're appended to the Handlers on build.
 	// Execution happens before Handlers, can be empty.
 	beginHandlers context.Handlers
@@ -27,9 +34,62 @@ type Route struct {
 	// temp storage, they're appended to the Handlers on build.
 	// Execution happens after Begin and main Handler(s), can be empty.
 	doneHandlers context.Handlers
+	// the names of the begin, main and done handlers, by the same order,
+	// empty for the anonymous ones, see `Party#RegisterMiddleware` and `Route#Chain`.
+	beginNames   []string
+	handlerNames []string
+	doneNames    []string
+	// the index of the route's first own handler, after the macro's handler and the middleware.
+	mainHandlerIndex int
+	// the names of the middleware that the route opts out of, see `Route#Except`.
+	excluded        []string
+	namedMiddleware *middlewareRegistry
 	// FormattedPath all dynamic named parameters (if any) replaced with %v,
 	// used by Application to validate param values of a Route based on its name.
 	FormattedPath string
+	// ControllerMethod is not nil when the route was registered by a controller's method function, i.e `GetBy`,
+	// it describes that method function, its input arguments and its results.
+	// It's being used by documentation generators, i.e the `openapi` package.
+	ControllerMethod *methodfunc.FuncInfo
+	// Consumes and Produces are the media types that the route accepts as request body (the "Content-Type")
+	// and the ones that it responds with (the "Accept"),
+	// routes that are sharing the same method and path are selected at serve time based on them.
+	// See `Route#Consume` and `Route#Produce`.
+	Consumes []string
+	Produces []string
+	// Version is the API version that the route is registered for, i.e "1.2.0",
+	// routes that are sharing the same method and path are selected at serve time
+	// based on the requested version, see `Party#Version`.
+	Version string
+	// the version range that is served when the request's version is missing, see `Party#DefaultVersion`.
+	defaultVersion string
+	// TrailingSlash is the policy for requests with a trailing slash, i.e "/orders/",
+	// it's inherited by the Party, see `Party#TrailingSlash`.
+	TrailingSlash TrailingSlashPolicy
+	// PathMatching is how the request paths that are not matching exactly the route's path are matched,
+	// i.e case-insensitively, it's inherited by the Party, see `Party#PathMatching`.
+	PathMatching PathMatching
+	// SourceFileName and SourceLineNumber are the location of the route's registration
+	// in the caller's code, if known, they're being shown by the `Trace` and the build diagnostics.
+	SourceFileName   string
+	SourceLineNumber int
+
+	// The route's metadata, they're optional and they don't affect the routing.
+	// They can be read by middleware through the `ctx.GetCurrentRoute()`
+	// and by documentation generators, i.e the `openapi` package.
+	//
+	// Summary is a short summary of what the route does.
+	Summary string
+	// Description is a verbose explanation of the route's behavior.
+	Description string
+	// Tags are used to group routes, i.e "users".
+	Tags []string
+	// Deprecated reports whether the route is deprecated,
+	// DeprecatedSince is the date of its deprecation, it can be zero.
+	Deprecated      bool
+	DeprecatedSince time.Time
+	// Meta contains any custom attributes, i.e "scope": "admin" or "rateLimit": "strict".
+	Meta memstore.Store
 }
 
 // NewRoute returns a new route based on its method,
@@ -50,34 +110,146 @@ func NewRoute(method, subdomain, unparsedPath, mainHandlerName string,
 		return nil, err
 	}
 
+	var subdomainTmpl *subdomainTemplate
+	if isDynamicSubdomain(subdomain) {
+		if subdomainTmpl, err = parseSubdomain(subdomain, macros); err != nil {
+			return nil, err
+		}
+	}
+
 	path = cleanPath(path) // maybe unnecessary here but who cares in this moment
-	defaultName := method + subdomain + tmpl.Src
 	formattedPath := formatPath(path)
 
 	route := &Route{
-		Name:            defaultName,
 		Method:          method,
 		Subdomain:       subdomain,
 		tmpl:            tmpl,
+		subdomainTmpl:   subdomainTmpl,
 		Path:            path,
 		Handlers:        handlers,
 		mainHandlerName: mainHandlerName,
 		FormattedPath:   formattedPath,
 	}
+	route.Name = route.defaultName()
 	return route, nil
 }
 
+// defaultName returns the route's name when a custom one is not given,
+// i.e "GET/users/{id:int}" or "GETshop.com/users/{id:int}" for a route of the "shop.com" host,
+// the routes of the same method and path on different hosts, versions or media types
+// should not share the same name, i.e "GET/users@1.0.0 produces=application/json".
+func (r *Route) defaultName() string {
+	name := r.Method + r.Host + r.Subdomain + r.tmpl.Src
+	if r.Version != "" {
+		name += "@" + r.Version
+	}
+	if len(r.Consumes) > 0 {
+		name += " consumes=" + strings.Join(r.Consumes, ",")
+	}
+	if len(r.Produces) > 0 {
+		name += " produces=" + strings.Join(r.Produces, ",")
+	}
+	return name
+}
+
+// HasDefaultName reports whether the route's name is the one that is given
+// by the router, i.e "GET/users/{id:int}", and not a custom one.
+func (r *Route) HasDefaultName() bool {
+	return r.Name == r.defaultName()
+}
+
+// Describe sets the route's summary and description.
+// Returns itself.
+func (r *Route) Describe(summary, description string) *Route {
+	r.Summary = summary
+	r.Description = description
+	return r
+}
+
+// Tag adds one or more tags to the route.
+// Returns itself.
+func (r *Route) Tag(tags ...string) *Route {
+	r.Tags = append(r.Tags, tags...)
+	return r
+}
+
+// Deprecate marks the route as deprecated since the "date",
+// the "date" can be zero if it's unknown.
+// Returns itself.
+func (r *Route) Deprecate(date time.Time) *Route {
+	r.Deprecated = true
+	r.DeprecatedSince = date
+	return r
+}
+
+// SetMeta sets a custom attribute to the route.
+// Returns itself.
+//
+// Example: app.Get("/admin", handler).SetMeta("scope", "admin")
+// and inside a middleware: ctx.GetCurrentRoute().Meta().GetString("scope").
+func (r *Route) SetMeta(key string, value interface{}) *Route {
+	r.Meta.Set(key, value)
+	return r
+}
+
+// Consume adds media types, i.e "application/json" or "multipart/form-data",
+// that the route accepts as request body.
+// If more than one routes are registered to the same method and path
+// then the router selects the route based on the request's "Content-Type" header,
+// if none of them can consume the request's body then the 415 status code is fired.
+// The media types are part of the route's default name.
+// Returns itself.
+func (r *Route) Consume(mediaTypes ...string) *Route {
+	defaultName := r.HasDefaultName()
+	for _, mediaType := range mediaTypes {
+		r.Consumes = append(r.Consumes, strings.ToLower(mediaType))
+	}
+	if defaultName {
+		r.Name = r.defaultName()
+	}
+	return r
+}
+
+// Produce adds media types, i.e "text/html" or "application/json",
+// that the route responds with.
+// If more than one routes are registered to the same method and path
+// then the router selects the route based on the request's "Accept" header,
+// if none of them can produce an acceptable response then the 406 status code is fired.
+// The media types are part of the route's default name.
+// Returns itself.
+func (r *Route) Produce(mediaTypes ...string) *Route {
+	defaultName := r.HasDefaultName()
+	for _, mediaType := range mediaTypes {
+		r.Produces = append(r.Produces, strings.ToLower(mediaType))
+	}
+	if defaultName {
+		r.Name = r.defaultName()
+	}
+	return r
+}
+
+// copyMetadata copies the metadata of the "from" route to this route.
+func (r *Route) copyMetadata(from *Route) {
+	r.Summary = from.Summary
+	r.Description = from.Description
+	r.Tags = from.Tags
+	r.Deprecated = from.Deprecated
+	r.DeprecatedSince = from.DeprecatedSince
+	r.Meta = from.Meta
+}
+
 // use adds explicit begin handlers(middleware) to this route,
 // It's being called internally, it's useless for outsiders
 // because `Handlers` field is exported.
 // The callers of this function are: `APIBuilder#UseGlobal` and `APIBuilder#Done`.
 //
 // BuildHandlers should be called to build the route's `Handlers`.
-func (r *Route) use(handlers context.Handlers) {
+func (r *Route) use(handlers context.Handlers, names []string) {
 	if len(handlers) == 0 {
 		return
 	}
 	r.beginHandlers = append(r.beginHandlers, handlers...)
+	r.beginNames = joinNames(r.beginNames, names)
 }
 
 // use adds explicit done handlers to this route.
@@ -86,32 +258,163 @@ func (r *Route) use(handlers context.Handlers) {
 // The callers of this function are: `APIBuilder#UseGlobal` and `APIBuilder#Done`.
 //
 // BuildHandlers should be called to build the route's `Handlers`.
-func (r *Route) done(handlers context.Handlers) {
+func (r *Route) done(handlers context.Handlers, names []string) {
 	if len(handlers) == 0 {
 		return
 	}
 	r.doneHandlers = append(r.doneHandlers, handlers...)
+	r.doneNames = joinNames(r.doneNames, names)
+}
+
+// UseNamed adds the middleware that are registered by their "names", see `Party#RegisterMiddleware`,
+// right before the route's own handlers, after the Party's middleware.
+// Returns itself.
+//
+// Example: app.Get("/admin", adminIndex).UseNamed("auth", "audit")
+func (r *Route) UseNamed(names ...string) *Route {
+	if r.namedMiddleware == nil {
+		return r
+	}
+
+	handlers, names := r.namedMiddleware.get(names)
+	if len(handlers) == 0 {
+		return r
+	}
+
+	r.syncNames()
+	i := r.mainHandlerIndex
+	r.Handlers = joinHandlers(joinHandlers(r.Handlers[:i], handlers), r.Handlers[i:])
+	r.handlerNames = joinNames(joinNames(r.handlerNames[:i], names), r.handlerNames[i:])
+	r.mainHandlerIndex += len(handlers)
+	return r
+}
+
+// Except removes the middleware that are registered by their "names", see `Party#RegisterMiddleware`,
+// from the route's handlers, i.e the Party's "auth" middleware from a health check route.
+// The middleware are removed on build, so the middleware that are registered after this call
+// are removed as well.
+// Returns itself.
+//
+// Example: api.Get("/health", health).Except("auth")
+func (r *Route) Except(names ...string) *Route {
+	for _, name := range names {
+		if r.namedMiddleware != nil && !r.namedMiddleware.exists(name) {
+			continue
+		}
+		r.excluded = append(r.excluded, name)
+	}
+	return r
+}
+
+// syncNames resets the names of the handlers if the `Handlers` field
+// was modified by the caller, the handlers are shown as anonymous then.
+func (r *Route) syncNames() {
+	if len(r.handlerNames) != len(r.Handlers) {
+		r.handlerNames = unnamed(len(r.Handlers))
+		r.mainHandlerIndex = 0
+	}
 }
 
-// BuildHandlers is executed automatically by the router handler
-// at the `Application#Build` state. Do not call it manually, unless
-// you were defined your own request mux handler.
+// chain returns the route's begin, main and done handlers, without the excluded ones,
+// their names and the index of the route's first own handler, the route is not modified.
+func (r *Route) chain() (context.Handlers, []string, int) {
+	var (
+		total    = len(r.beginHandlers) + len(r.Handlers) + len(r.doneHandlers)
+		handlers = make(context.Handlers, 0, total)
+		names    = make([]string, 0, total)
+		// the begin handlers are going before the main ones.
+		main    = len(r.beginHandlers) + r.mainHandlerIndex
+		newMain = main
+		pos     = 0
+	)
+
+	handlerNames := r.handlerNames
+	if len(handlerNames) != len(r.Handlers) {
+		handlerNames = unnamed(len(r.Handlers))
+		main, newMain = len(r.beginHandlers), len(r.beginHandlers)
+	}
+
+	add := func(hs context.Handlers, hsNames []string) {
+		for i, h := range hs {
+			name := ""
+			if i < len(hsNames) {
+				name = hsNames[i]
+			}
+
+			if name != "" && containsString(r.excluded, name) {
+				if pos < main {
+					newMain--
+				}
+			} else {
+				handlers = append(handlers, h)
+				names = append(names, name)
+			}
+			pos++
+		}
+	}
+
+	add(r.beginHandlers, r.beginNames)
+	add(r.Handlers, handlerNames)
+	add(r.doneHandlers, r.doneNames)
+
+	return handlers, names, newMain
+}
+
+// BuildHandlers builds the route's `Handlers` based on its begin and done handlers.
+// The default router handler calls it on copies of the registered routes, see `built`,
+// at the `Application#Build` state and on each `RefreshRouter`,
+// the built handlers are written back to the registered routes when the build succeed.
+// Do not call it manually, unless you were defined your own request mux handler.
 func (r *Route) BuildHandlers() {
-	if len(r.beginHandlers) > 0 {
-		r.Handlers = append(r.beginHandlers, r.Handlers...)
-		r.beginHandlers = r.beginHandlers[0:0]
+	if len(r.beginHandlers) == 0 && len(r.doneHandlers) == 0 && len(r.excluded) == 0 {
+		return
 	}
 
-	if len(r.doneHandlers) > 0 {
-		r.Handlers = append(r.Handlers, r.doneHandlers...)
-		r.doneHandlers = r.doneHandlers[0:0]
-	} // note: no mutex needed, this should be called in-sync when server is not running of course.
+	r.Handlers, r.handlerNames, r.mainHandlerIndex = r.chain()
+	r.beginHandlers, r.beginNames = r.beginHandlers[0:0], nil
+	r.doneHandlers, r.doneNames = r.doneHandlers[0:0], nil
+	// note: no mutex needed, this should be called in-sync when server is not running of course.
+}
+
+// built returns a copy of the route with its `Handlers` built, see `BuildHandlers`,
+// the route itself is not modified, so the routes that are served
+// by the current trees are not changed while the router is refreshed.
+func (r *Route) built() *Route {
+	b := *r
+	// the metadata can be modified after build, do not share its entries with the served route.
+	b.Meta = append(memstore.Store(nil), r.Meta...)
+	b.BuildHandlers()
+	return &b
+}
+
+// setBuilt writes the built handlers of "b", see `built`, back to the route,
+// so the registered routes show the same handlers as the served ones.
+func (r *Route) setBuilt(b *Route) {
+	r.Handlers, r.handlerNames, r.mainHandlerIndex = b.Handlers, b.handlerNames, b.mainHandlerIndex
+	r.beginHandlers, r.beginNames = nil, nil
+	r.doneHandlers, r.doneNames = nil, nil
+}
+
+// Chain returns the names of the route's handlers by their execution order,
+// the named middleware, see `Party#RegisterMiddleware`, are shown by their registered names
+// and the rest of the handlers by their function's name.
+// The middleware that the route opts out of, see `Except`, are not included.
+//
+// It can be used to audit the routes, i.e that every route of a Party is protected by the "auth" middleware.
+func (r Route) Chain() []string {
+	handlers, names, _ := r.chain()
+	for i, name := range names {
+		if name == "" {
+			names[i] = context.HandlerName(handlers[i])
+		}
+	}
+	return names
 }
 
 // String returns the form of METHOD, SUBDOMAIN, TMPL PATH.
 func (r Route) String() string {
-	return fmt.Sprintf("%s %s%s",
-		r.Method, r.Subdomain, r.Tmpl().Src)
+	return fmt.Sprintf("%s %s%s%s",
+		r.Method, r.Host, r.Subdomain, r.Tmpl().Src)
 }
 
 // Tmpl returns the path template, i
@@ -208,21 +511,32 @@ func (r Route) ResolvePath(args ...string) string {
 // Trace returns some debug infos as a string sentence.
 // Should be called after Build.
 func (r Route) Trace() string {
+	if l := len(r.Handlers); l > 1 {
+		return r.trace(fmt.Sprintf("%s() and %d more", r.mainHandlerName, l-1))
+	}
+	return r.trace(fmt.Sprintf("%s()", r.mainHandlerName))
+}
+
+// TraceChain same as `Trace` but it lists the whole handlers chain of the route, see `Chain`,
+// i.e "GET: /api/users -> auth, logger, main.getUsers (main.go:42)".
+func (r Route) TraceChain() string {
+	return r.trace(strings.Join(r.Chain(), ", "))
+}
+
+func (r Route) trace(handlers string) string {
 	printfmt := fmt.Sprintf("%s:", r.Method)
+	if r.Host != "" {
+		printfmt += fmt.Sprintf(" %s", r.Host)
+	}
 	if r.Subdomain != "" {
 		printfmt += fmt.Sprintf(" %s", r.Subdomain)
 	}
-	printfmt += fmt.Sprintf(" %s ", r.Tmpl().Src)
-	if l := len(r.Handlers); l > 1 {
-		printfmt += fmt.Sprintf("-> %s() and %d more", r.mainHandlerName, l-1)
-	} else {
-		printfmt += fmt.Sprintf("-> %s()", r.mainHandlerName)
+	printfmt += fmt.Sprintf(" %s -> %s", r.Tmpl().Src, handlers)
+
+	if r.SourceFileName != "" {
+		printfmt += fmt.Sprintf(" (%s:%d)", r.SourceFileName, r.SourceLineNumber)
 	}
 
-	// printfmt := fmt.Sprintf("%s: %s >> %s", r.Method, r.Subdomain+r.Tmpl().Src, r.mainHandlerName)
-	// if l := len(r.Handlers); l > 0 {
-	// 	printfmt += fmt.Sprintf(" and %d more", l)
-	// }
 	return printfmt // without new line.
 }
 
@@ -249,3 +563,33 @@ func (rd routeReadOnlyWrapper) Path() string {
 func (rd routeReadOnlyWrapper) Trace() string {
 	return rd.Route.Trace()
 }
+
+func (rd routeReadOnlyWrapper) Summary() string {
+	return rd.Route.Summary
+}
+
+func (rd routeReadOnlyWrapper) Description() string {
+	return rd.Route.Description
+}
+
+func (rd routeReadOnlyWrapper) Tags() []string {
+	return rd.Route.Tags
+}
+
+func (rd routeReadOnlyWrapper) Deprecated() bool {
+	return rd.Route.Deprecated
+}
+
+func (rd routeReadOnlyWrapper) DeprecatedSince() time.Time {
+	return rd.Route.DeprecatedSince
+}
+
+// routeMetaReadOnly hides the store's methods that modify the attributes,
+// the store can't be accessed through a type assertion either.
+type routeMetaReadOnly struct {
+	context.RouteMetaReadOnly
+}
+
+func (rd routeReadOnlyWrapper) Meta() context.RouteMetaReadOnly {
+	return routeMetaReadOnly{&rd.Route.Meta}
+}
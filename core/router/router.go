@@ -3,6 +3,7 @@ package router
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/kataras/iris/context"
 	"github.com/kataras/iris/core/errors"
@@ -13,13 +14,16 @@ import (
 // Router is responsible to build the received request handler and run it
 // to serve requests, based on the received context.Pool.
 //
-// User can refresh the router with `RefreshRouter` whenever a route's field is changed by him.
+// User can refresh the router with `RefreshRouter` whenever a route's field is changed by him,
+// or a route is removed or replaced, even at serve-time.
 type Router struct {
-	mu sync.Mutex // for Downgrade, WrapRouter & BuildRouter,
+	mu sync.Mutex // for Downgrade, WrapRouter, BuildRouter & RefreshRouter,
 	// not indeed but we don't to risk its usage by third-parties.
-	requestHandler RequestHandler   // build-accessible, can be changed to define a custom router or proxy, used on RefreshRouter too.
-	mainHandler    http.HandlerFunc // init-accessible
-	wrapperFunc    func(http.ResponseWriter, *http.Request, http.HandlerFunc)
+	requestHandler RequestHandler // build-accessible, can be changed to define a custom router or proxy, used on RefreshRouter too.
+	// the http.HandlerFunc which serves the requests, init-accessible,
+	// stored atomically because it can be replaced at serve-time.
+	mainHandler atomic.Value
+	wrapperFunc func(http.ResponseWriter, *http.Request, http.HandlerFunc)
 
 	cPool          *context.Pool // used on RefreshRouter
 	routesProvider RoutesProvider
@@ -29,9 +33,16 @@ type Router struct {
 func NewRouter() *Router { return &Router{} }
 
 // RefreshRouter re-builds the router. Should be called when a route's state
-// changed (i.e Method changed at serve-time).
+// changed (i.e Method changed at serve-time) or a route was removed or replaced.
+//
+// It's safe to be called at serve-time, the in-flight requests
+// are served by the previous routes and the new requests by the new ones.
 func (router *Router) RefreshRouter() error {
-	return router.BuildRouter(router.cPool, router.requestHandler, router.routesProvider)
+	router.mu.Lock()
+	cPool, requestHandler, routesProvider := router.cPool, router.requestHandler, router.routesProvider
+	router.mu.Unlock()
+
+	return router.BuildRouter(cPool, requestHandler, routesProvider)
 }
 
 // BuildRouter builds the router based on
@@ -51,31 +62,40 @@ func (router *Router) BuildRouter(cPool *context.Pool, requestHandler RequestHan
 		return errors.New("router: context pool is nil")
 	}
 
-	// build the handler using the routesProvider
-	if err := requestHandler.Build(routesProvider); err != nil {
-		return err
-	}
-
 	router.mu.Lock()
 	defer router.mu.Unlock()
 
+	// build the handler using the routesProvider,
+	// on failure the request handler keeps its previous routes, if any.
+	err := requestHandler.Build(routesProvider)
+	if err != nil && router.mainHandler.Load() != nil {
+		// keep serving through the previous main handler.
+		return err
+	}
+
 	// store these for RefreshRouter's needs.
 	router.cPool = cPool
-	router.requestHandler = requestHandler
+	if router.requestHandler != requestHandler {
+		// set only if changed, it's being read by the ServeHTTPC.
+		router.requestHandler = requestHandler
+	}
 	router.routesProvider = routesProvider
 
 	// the important
-	router.mainHandler = func(w http.ResponseWriter, r *http.Request) {
+	mainHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := cPool.Acquire(w, r)
-		router.requestHandler.HandleRequest(ctx)
+		requestHandler.HandleRequest(ctx)
 		cPool.Release(ctx)
-	}
+	})
 
 	if router.wrapperFunc != nil { // if wrapper used then attach that as the router service
-		router.mainHandler = NewWrapper(router.wrapperFunc, router.mainHandler).ServeHTTP
+		mainHandler = NewWrapper(router.wrapperFunc, mainHandler).ServeHTTP
 	}
 
-	return nil
+	// stored even if the first build failed, so the router can serve,
+	// i.e the 404s, and it can be refreshed when the routes are fixed.
+	router.mainHandler.Store(mainHandler)
+	return err
 }
 
 // Downgrade "downgrades", alters the router supervisor service(Router.mainHandler)
@@ -89,13 +109,13 @@ func (router *Router) BuildRouter(cPool *context.Pool, requestHandler RequestHan
 // Downgrade is thread-safe.
 func (router *Router) Downgrade(newMainHandler http.HandlerFunc) {
 	router.mu.Lock()
-	router.mainHandler = newMainHandler
+	router.mainHandler.Store(newMainHandler)
 	router.mu.Unlock()
 }
 
 // Downgraded returns true if this router is downgraded.
 func (router *Router) Downgraded() bool {
-	return router.mainHandler != nil && router.requestHandler == nil
+	return router.mainHandler.Load() != nil && router.requestHandler == nil
 }
 
 // WrapperFunc is used as an expected input parameter signature
@@ -144,7 +164,7 @@ func (router *Router) ServeHTTPC(ctx context.Context) {
 }
 
 func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	router.mainHandler(w, r)
+	router.mainHandler.Load().(http.HandlerFunc)(w, r)
 }
 
 type wrapper struct {
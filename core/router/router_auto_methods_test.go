@@ -0,0 +1,64 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterAutoHeadAndOptions(t *testing.T) {
+	app := iris.New()
+	app.Configure(iris.WithAutoHead, iris.WithAutoOptions, iris.WithFireMethodNotAllowed)
+
+	app.Get("/users/{id:int}", func(ctx context.Context) {
+		ctx.Header("X-User", ctx.Params().Get("id"))
+		ctx.WriteString("user")
+	})
+	app.Post("/users/{id:int}", func(ctx context.Context) {})
+	app.Put("/posts", func(ctx context.Context) {})
+	app.Options("/posts", func(ctx context.Context) {
+		ctx.WriteString("custom options")
+	})
+
+	e := httptest.New(t, app)
+
+	e.HEAD("/users/42").Expect().Status(httptest.StatusOK).
+		Header("X-User").Equal("42")
+	e.HEAD("/users/42").Expect().
+		Header("Content-Length").Equal("4")
+	e.HEAD("/users/42").Expect().
+		Body().Empty()
+	e.HEAD("/users/kataras").Expect().Status(httptest.StatusNotFound)
+
+	e.OPTIONS("/users/42").Expect().Status(httptest.StatusOK).
+		Header("Allow").Equal("GET, POST, HEAD, OPTIONS")
+	e.OPTIONS("/users/kataras").Expect().Status(httptest.StatusNotFound)
+	// registered OPTIONS routes are not overridden.
+	e.OPTIONS("/posts").Expect().Status(httptest.StatusOK).
+		Body().Equal("custom options")
+
+	e.DELETE("/users/42").Expect().Status(httptest.StatusMethodNotAllowed).
+		Header("Allow").Equal("GET, POST, HEAD, OPTIONS")
+	e.GET("/posts").Expect().Status(httptest.StatusMethodNotAllowed).
+		Header("Allow").Equal("PUT, OPTIONS")
+}
+
+func TestRouterAutoHeadAndOptionsDisabled(t *testing.T) {
+	app := iris.New()
+	app.Configure(iris.WithFireMethodNotAllowed)
+
+	app.Get("/users/{id:int}", func(ctx context.Context) {
+		ctx.WriteString("user")
+	})
+
+	e := httptest.New(t, app)
+
+	e.HEAD("/users/42").Expect().Status(httptest.StatusMethodNotAllowed).
+		Header("Allow").Equal("GET")
+	e.OPTIONS("/users/42").Expect().Status(httptest.StatusMethodNotAllowed).
+		Header("Allow").Equal("GET")
+}
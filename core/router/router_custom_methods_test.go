@@ -0,0 +1,64 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/router"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterCustomMethods(t *testing.T) {
+	// remove the custom methods, the `Any` routes of the rest of the tests are registered to them too.
+	defer router.ResetCustomMethods()
+	iris.RegisterMethod("PROPFIND", "MKCOL", "QUERY")
+	if expected, got := len(router.AllMethods)+3, len(router.Methods()); expected != got {
+		t.Fatalf("expected %d methods but got %d", expected, got)
+	}
+	// registered already.
+	iris.RegisterMethod("PROPFIND", "GET")
+
+	count := 0
+	for _, m := range router.Methods() {
+		if m == "PROPFIND" || m == "GET" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Fatalf("expected the methods to be registered once but got: %v", router.Methods())
+	}
+
+	app := iris.New()
+	app.Configure(iris.WithAutoOptions, iris.WithFireMethodNotAllowed)
+
+	writeMethod := func(ctx context.Context) {
+		ctx.WriteString(ctx.Method())
+	}
+	app.Handle("PROPFIND", "/files/{name:path}", writeMethod)
+	app.Handle("MKCOL", "/files/{name:path}", writeMethod)
+	app.Any("/any", writeMethod)
+
+	e := httptest.New(t, app)
+	e.Request("PROPFIND", "/files/docs/a.txt").Expect().Status(httptest.StatusOK).Body().Equal("PROPFIND")
+	e.Request("MKCOL", "/files/docs").Expect().Status(httptest.StatusOK).Body().Equal("MKCOL")
+	e.Request("QUERY", "/any").Expect().Status(httptest.StatusOK).Body().Equal("QUERY")
+	e.Request("GET", "/any").Expect().Status(httptest.StatusOK).Body().Equal("GET")
+
+	e.OPTIONS("/files/docs").Expect().Status(httptest.StatusOK).
+		Header("Allow").Equal("PROPFIND, MKCOL, OPTIONS")
+	e.Request("LOCK", "/files/docs").Expect().Status(httptest.StatusMethodNotAllowed).
+		Header("Allow").Equal("PROPFIND, MKCOL, OPTIONS")
+}
+
+func TestRouterRegisterInvalidMethod(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for an invalid method")
+		}
+	}()
+
+	iris.RegisterMethod("PROP FIND")
+}
@@ -0,0 +1,77 @@
+// black-box testing
+package router_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/core/router"
+)
+
+// callerLine returns the line number of its caller.
+func callerLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestRouterDiagnostics(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/{id:int}", writeText("user"))
+	shadowedLine := callerLine() + 1
+	app.Get("/users/{id:int min(1)}", writeText("shadowed"))
+	app.Get("/posts/{id:int min(1)}", writeText("post"))
+	app.Get("/posts/{id:int}", writeText("not shadowed, it serves the 0"))
+	neverLine := callerLine() + 1
+	app.Get("/items/{id:int min(10) max(5)}", writeText("never"))
+	app.Get("/files/{name:string min(3) max(2)}", writeText("never"))
+	app.Host("*.com").Get("/", writeText("com"))
+	unreachableLine := callerLine() + 1
+	app.Host("*.shop.com").Get("/", writeText("unreachable"))
+
+	if err := router.Diagnose(app.GetRoutes()); err == nil {
+		t.Fatalf("expected diagnostics before the build too")
+	}
+
+	app.Configure(iris.WithRouteWarningsAsErrors)
+	err := app.Build()
+	if err == nil {
+		t.Fatalf("expected the build to fail on warnings")
+	}
+
+	expected := []string{
+		"GET: /users/{id:int min(1)} -> ",
+		fmt.Sprintf("router_diagnostics_test.go:%d) is shadowed by GET: /users/{id:int} ", shadowedLine),
+		fmt.Sprintf("router_diagnostics_test.go:%d): the parameter {id:int min(10) max(5)} can never match", neverLine),
+		"the parameter {name:string min(3) max(2)} can never match",
+		fmt.Sprintf("router_diagnostics_test.go:%d) is unreachable, its host pattern is matched by the host pattern \"*.com\"", unreachableLine),
+	}
+	for _, s := range expected {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected the build error to contain %q but got:\n%v", s, err)
+		}
+	}
+
+	if got := strings.Count(err.Error(), "\n") + 1; got != len(expected)-1 {
+		t.Fatalf("expected %d warnings but got %d:\n%v", len(expected)-1, got, err)
+	}
+}
+
+func TestRouterDiagnosticsCatchAllHost(t *testing.T) {
+	app := iris.New()
+	app.Host("*").Get("/", writeText("any host"))
+	app.Get("/about", writeText("unreachable"))
+
+	err := router.Diagnose(app.GetRoutes())
+	if err == nil || !strings.Contains(err.Error(), "GET: /about") ||
+		!strings.Contains(err.Error(), "all of the hosts are matched by the host pattern \"*\"") {
+		t.Fatalf("expected the route without a host to be unreachable but got: %v", err)
+	}
+
+	// warnings are logged only.
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+}
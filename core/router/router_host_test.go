@@ -0,0 +1,74 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterHosts(t *testing.T) {
+	app := iris.New()
+	app.Get("/", writeText("default"))
+
+	shop := app.Host("shop.com", func(ctx context.Context) {
+		ctx.Header("X-Shop", "true")
+		ctx.Next()
+	})
+	shop.Get("/", writeText("shop"))
+	shop.OnErrorCode(iris.StatusNotFound, writeText("shop not found"))
+
+	app.Host("*.shop.*").Get("/", func(ctx context.Context) {
+		ctx.Writef("localized shop %s", ctx.Host())
+	})
+	app.Host("shop.de").Get("/", writeText("shop de"))
+
+	admin := app.Host("Admin.Internal")
+	admin.Get("/users/{id:int}", writeText("admin user"))
+
+	e := httptest.New(t, app)
+
+	e.GET("/").WithURL("http://example.com").Expect().Status(httptest.StatusOK).Body().Equal("default")
+	e.GET("/").WithURL("http://shop.com:8080").Expect().Status(httptest.StatusOK).
+		Header("X-Shop").Equal("true")
+	e.GET("/").WithURL("http://shop.com").Expect().Status(httptest.StatusOK).Body().Equal("shop")
+	e.GET("/").WithURL("http://shop.de").Expect().Status(httptest.StatusOK).Body().Equal("shop de")
+	e.GET("/").WithURL("http://www.shop.fr").Expect().Status(httptest.StatusOK).
+		Body().Equal("localized shop www.shop.fr")
+	e.GET("/users/42").WithURL("http://admin.internal").Expect().Status(httptest.StatusOK).
+		Body().Equal("admin user")
+
+	// host's routes and error handlers are not visible to the rest hosts.
+	e.GET("/notfound").WithURL("http://shop.com").Expect().Status(httptest.StatusNotFound).
+		Body().Equal("shop not found")
+	e.GET("/notfound").WithURL("http://example.com").Expect().Status(httptest.StatusNotFound).
+		Body().Equal("Not Found")
+	e.GET("/users/42").WithURL("http://example.com").Expect().Status(httptest.StatusNotFound)
+	e.GET("/").WithURL("http://admin.internal").Expect().Status(httptest.StatusNotFound)
+}
+
+func TestRouterHostsCurrentRoute(t *testing.T) {
+	app := iris.New()
+
+	writeScope := func(ctx context.Context) {
+		r := ctx.GetCurrentRoute()
+		ctx.Writef("%s %s", r.Meta().GetString("scope"), r.Name())
+	}
+
+	app.Get("/", writeScope).SetMeta("scope", "public")
+	app.Host("admin.internal").Get("/", writeScope).SetMeta("scope", "admin")
+	// a custom name which is shared between the routes.
+	app.Get("/users", writeScope).SetMeta("scope", "public").Name = "users"
+	app.Host("admin.internal").Get("/users", writeScope).SetMeta("scope", "admin").Name = "users"
+
+	e := httptest.New(t, app)
+
+	e.GET("/").WithURL("http://example.com").Expect().Status(httptest.StatusOK).Body().Equal("public GET/")
+	e.GET("/").WithURL("http://admin.internal").Expect().Status(httptest.StatusOK).
+		Body().Equal("admin GETadmin.internal/")
+	e.GET("/users").WithURL("http://example.com").Expect().Status(httptest.StatusOK).Body().Equal("public users")
+	e.GET("/users").WithURL("http://admin.internal").Expect().Status(httptest.StatusOK).Body().Equal("admin users")
+}
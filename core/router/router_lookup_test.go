@@ -0,0 +1,46 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func writeRouteAndParams(ctx context.Context) {
+	ctx.Writef("%s", ctx.GetCurrentRoute().Path())
+	ctx.Params().Visit(func(key, value string) {
+		ctx.Writef(" %s=%s", key, value)
+	})
+}
+
+func TestRouterLookupBacktracking(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/me", writeRouteAndParams)
+	app.Get("/users/me/settings", writeRouteAndParams)
+	app.Get("/users/{id:int}/posts", writeRouteAndParams)
+	app.Get("/users/{name:alphabetical}/likes", writeRouteAndParams)
+	app.Get("/users/{name}/files/{file:path}", writeRouteAndParams)
+	app.Get("/{p:path}", writeRouteAndParams)
+
+	e := httptest.New(t, app)
+	tests := []struct{ path, body string }{
+		{"/users/me", "/users/me"},
+		{"/users/me/settings", "/users/me/settings"},
+		// static "me" can't serve it, the parameter does.
+		{"/users/me/likes", "/users/{name:alphabetical}/likes name=me"},
+		{"/users/42/posts", "/users/{id:int}/posts id=42"},
+		// "id" is not an int, the parameter's branch can't serve it, the root wildcard does.
+		{"/users/kataras/posts", "/{p:path} p=users/kataras/posts"},
+		{"/users/kataras/files", "/users/{name}/files/{file:path} name=kataras file="},
+		{"/users/kataras/files/a/b.txt", "/users/{name}/files/{file:path} name=kataras file=a/b.txt"},
+		{"/users", "/{p:path} p=users"},
+	}
+
+	for _, tt := range tests {
+		e.GET(tt.path).Expect().Status(httptest.StatusOK).Body().Equal(tt.body)
+	}
+}
@@ -0,0 +1,161 @@
+// black-box testing
+//
+// see _examples/routing/main_test.go for the most common router tests that you may want to see,
+// this is a test which makes sure that routes which are sharing the same path
+// but with different macro param types or param funcs can coexist
+// and that custom macro param types can be registered.
+
+package router_test
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/router/macro"
+	"github.com/kataras/iris/httptest"
+
+	"github.com/satori/go.uuid"
+)
+
+func writeParams(prefix string) context.Handler {
+	return func(ctx context.Context) {
+		ctx.WriteString(prefix)
+		ctx.Params().Visit(func(key string, value string) {
+			ctx.WriteString(" " + key + "=" + value)
+		})
+	}
+}
+
+func TestRouterMacroTypesOnSamePath(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/{id:int}", writeParams("id"))
+	app.Get("/users/{username:alphabetical}", writeParams("username"))
+	app.Get("/users/me", writeParams("me"))
+
+	app.Get("/posts/{id:int min(10)}/comments", writeParams("id"))
+	app.Get("/posts/{slug}/comments", writeParams("slug"))
+
+	app.Get("/files/{name:string prefix(img_)}", writeParams("image"))
+	app.Get("/files/{p:path}", writeParams("path"))
+
+	app.Get("/items/{id:int else 400}", writeParams("item"))
+
+	e := httptest.New(t, app)
+
+	e.GET("/users/42").Expect().Status(httptest.StatusOK).Body().Equal("id id=42")
+	e.GET("/users/kataras").Expect().Status(httptest.StatusOK).Body().Equal("username username=kataras")
+	e.GET("/users/me").Expect().Status(httptest.StatusOK).Body().Equal("me")
+	e.GET("/users/kataras42").Expect().Status(httptest.StatusNotFound)
+
+	e.GET("/posts/42/comments").Expect().Status(httptest.StatusOK).Body().Equal("id id=42")
+	e.GET("/posts/5/comments").Expect().Status(httptest.StatusOK).Body().Equal("slug slug=5")
+	e.GET("/posts/iris/comments").Expect().Status(httptest.StatusOK).Body().Equal("slug slug=iris")
+
+	e.GET("/files/img_logo.png").Expect().Status(httptest.StatusOK).Body().Equal("image name=img_logo.png")
+	e.GET("/files/docs/readme.md").Expect().Status(httptest.StatusOK).Body().Equal("path p=docs/readme.md")
+	e.GET("/files/readme.md").Expect().Status(httptest.StatusOK).Body().Equal("path p=readme.md")
+
+	// explicit error codes are still respected.
+	e.GET("/items/42").Expect().Status(httptest.StatusOK).Body().Equal("item id=42")
+	e.GET("/items/abc").Expect().Status(httptest.StatusBadRequest)
+}
+
+func TestRouterMacroAmbiguousRoutes(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/{id:int min(1)}", writeParams("id"))
+	app.Get("/users/{userid:int  min(1)}", writeParams("userid"))
+
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for ambiguous routes but got nil")
+	}
+
+	app = iris.New()
+	app.Get("/users/{id}", writeParams("id"))
+	app.Get("/users/{username:string}", writeParams("username"))
+
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for ambiguous routes but got nil")
+	}
+}
+
+func TestRouterMacroCustomParamType(t *testing.T) {
+	app := iris.New()
+	uuidMacro := app.Macros().Register("uuid",
+		macro.MustNewEvaluatorFromRegexp("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"),
+		uuid.FromString)
+	uuidMacro.RegisterFunc("version", func(version int) func(string) bool {
+		return func(paramValue string) bool {
+			id, err := uuid.FromString(paramValue)
+			return err == nil && int(id.Version()) == version
+		}
+	})
+
+	app.Get("/users/{id:uuid}", func(ctx context.Context) {
+		id, ok := ctx.Params().GetValue("id").(uuid.UUID)
+		if !ok {
+			ctx.StatusCode(httptest.StatusInternalServerError)
+			return
+		}
+		ctx.Writef("uuid %s", id)
+	})
+	app.Get("/users/{username}", writeParams("username"))
+	app.Get("/sessions/{id:uuid version(4)}", writeParams("session"))
+
+	e := httptest.New(t, app)
+
+	id := "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+	e.GET("/users/" + id).Expect().Status(httptest.StatusOK).Body().Equal("uuid " + id)
+	e.GET("/users/kataras").Expect().Status(httptest.StatusOK).Body().Equal("username username=kataras")
+	e.GET("/sessions/" + id).Expect().Status(httptest.StatusOK).Body().Equal("session id=" + id)
+	e.GET("/sessions/6ba7b810-9dad-11d1-80b4-00c04fd430c8").Expect().Status(httptest.StatusNotFound)
+
+	// custom param types are registered per application.
+	app = iris.New()
+	app.Get("/users/{id:uuid}", writeParams("id"))
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for a not registered param type but got nil")
+	}
+}
+
+func TestRouterMacroCustomParamTypePerApplication(t *testing.T) {
+	newApp := func(converter interface{}) *iris.Application {
+		app := iris.New()
+		app.Macros().Register("code", macro.MustNewEvaluatorFromRegexp("^[0-9]+$"), converter)
+		app.Get("/codes/{code:code}", func(ctx context.Context) {
+			ctx.Writef("%T", ctx.Params().GetValue("code"))
+		})
+		return app
+	}
+
+	var (
+		apps = make([]*iris.Application, 2)
+		wg   sync.WaitGroup
+	)
+	// the same identifier, registered concurrently with different converters.
+	wg.Add(len(apps))
+	go func() { defer wg.Done(); apps[0] = newApp(strconv.Atoi) }()
+	go func() {
+		defer wg.Done()
+		apps[1] = newApp(func(paramValue string) ([]string, error) { return strings.Split(paramValue, ""), nil })
+	}()
+	wg.Wait()
+
+	for i, expected := range []string{"int", "[]string"} {
+		e := httptest.New(t, apps[i])
+		e.GET("/codes/42").Expect().Status(httptest.StatusOK).Body().Equal(expected)
+	}
+
+	if got := fmt.Sprint(apps[0].Macros().LookupConvertedType(reflect.TypeOf(0))); got != "code" {
+		t.Fatalf("expected the param type %q but got %q", "code", got)
+	}
+
+	if got := apps[1].Macros().LookupConvertedType(reflect.TypeOf(0)); got.IsCustom() {
+		t.Fatalf("expected no param type for the int of the second application but got %q", got)
+	}
+}
@@ -0,0 +1,85 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterMediaTypes(t *testing.T) {
+	app := iris.New()
+
+	app.Get("/users", writeText("users html")).Produce("text/html").Name = "usersHTML"
+	app.Get("/users", writeText("users json")).Produce("application/json")
+
+	app.Post("/users", writeText("created from json")).Consume("application/json")
+	app.Post("/users", writeText("created from form")).Consume("multipart/form-data", "application/x-www-form-urlencoded")
+
+	app.Put("/users", writeText("updated")).Consume("application/json")
+	app.Delete("/users", writeText("deleted")).Consume("application/json")
+
+	// fallback to the route without constraints.
+	app.Get("/articles", writeText("articles json")).Produce("application/json")
+	app.Get("/articles", func(ctx context.Context) {
+		ctx.WriteString(ctx.GetCurrentRoute().Name())
+	}).Name = "articles"
+
+	e := httptest.New(t, app)
+
+	e.GET("/users").WithHeader("Accept", "text/html,application/xhtml+xml,*/*;q=0.8").Expect().
+		Status(httptest.StatusOK).Body().Equal("users html")
+	e.GET("/users").WithHeader("Accept", "application/json").Expect().
+		Status(httptest.StatusOK).Body().Equal("users json")
+	e.GET("/users").WithHeader("Accept", "text/html;q=0.5, application/*").Expect().
+		Status(httptest.StatusOK).Header("Vary").Equal("Accept")
+	e.GET("/users").WithHeader("Accept", "text/html;q=0.5, application/*").Expect().
+		Body().Equal("users json")
+	// no Accept header, first registered wins.
+	e.GET("/users").Expect().Status(httptest.StatusOK).Body().Equal("users html")
+	e.GET("/users").WithHeader("Accept", "image/png").Expect().Status(httptest.StatusNotAcceptable)
+
+	e.POST("/users").WithJSON(iris.Map{"name": "kataras"}).Expect().
+		Status(httptest.StatusOK).Body().Equal("created from json")
+	e.POST("/users").WithFormField("name", "kataras").Expect().
+		Status(httptest.StatusOK).Body().Equal("created from form")
+	e.POST("/users").WithMultipart().WithFormField("name", "kataras").Expect().
+		Status(httptest.StatusOK).Body().Equal("created from form")
+	e.POST("/users").WithText("kataras").Expect().Status(httptest.StatusUnsupportedMediaType)
+
+	// a single route with constraints.
+	e.PUT("/users").WithJSON(iris.Map{"name": "kataras"}).Expect().
+		Status(httptest.StatusOK).Body().Equal("updated")
+	e.PUT("/users").WithText("kataras").Expect().Status(httptest.StatusUnsupportedMediaType)
+	e.PUT("/users").WithBytes([]byte("kataras")).Expect().Status(httptest.StatusUnsupportedMediaType)
+	// nothing to consume.
+	e.DELETE("/users").Expect().Status(httptest.StatusOK).Body().Equal("deleted")
+
+	e.GET("/articles").WithHeader("Accept", "application/json").Expect().
+		Status(httptest.StatusOK).Body().Equal("articles json")
+	e.GET("/articles").WithHeader("Accept", "text/html").Expect().
+		Status(httptest.StatusOK).Body().Equal("articles")
+}
+
+func TestRouterMediaTypesConflict(t *testing.T) {
+	app := iris.New()
+	app.Get("/users", writeText("users")).Produce("application/json")
+	app.Get("/users", writeText("users")).Produce("application/json")
+
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for routes with the same path and media types")
+	}
+}
+
+func TestRouterMediaTypesParamNamesConflict(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/{id}", writeText("user json")).Produce("application/json")
+	app.Get("/users/{name}", writeText("user html")).Produce("text/html")
+
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for routes with the same path but different param names")
+	}
+}
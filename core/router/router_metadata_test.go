@@ -0,0 +1,65 @@
+// black-box testing
+package router_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/memstore"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouteMetadata(t *testing.T) {
+	app := iris.New()
+
+	deprecatedSince := time.Date(2017, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	requireScope := func(ctx context.Context) {
+		// the route's metadata can't be modified while serving.
+		if _, ok := ctx.GetCurrentRoute().Meta().(*memstore.Store); ok {
+			ctx.StatusCode(iris.StatusInternalServerError)
+			return
+		}
+
+		if scope := ctx.GetCurrentRoute().Meta().GetString("scope"); scope != "" && ctx.GetHeader("X-Scope") != scope {
+			ctx.StatusCode(iris.StatusForbidden)
+			return
+		}
+		ctx.Next()
+	}
+
+	describe := func(ctx context.Context) {
+		r := ctx.GetCurrentRoute()
+		ctx.Writef("%s|%s|%s|%v|%s", r.Summary(), r.Description(), strings.Join(r.Tags(), ","),
+			r.Deprecated(), r.DeprecatedSince().Format("2006-01-02"))
+	}
+
+	app.Use(requireScope)
+	app.Get("/users", describe).
+		Describe("List users", "Lists all the users").
+		Tag("users", "public")
+	app.Get("/admin", describe).
+		Tag("admin").
+		Deprecate(deprecatedSince).
+		SetMeta("scope", "admin")
+
+	e := httptest.New(t, app)
+	e.GET("/users").Expect().Status(httptest.StatusOK).
+		Body().Equal("List users|Lists all the users|users,public|false|0001-01-01")
+	e.GET("/admin").Expect().Status(httptest.StatusForbidden)
+	e.GET("/admin").WithHeader("X-Scope", "admin").Expect().Status(httptest.StatusOK).
+		Body().Equal("||admin|true|2017-11-01")
+
+	// replaced routes keep their metadata.
+	app.ReplaceRoute(iris.MethodGet, "/admin", writeText("replaced"))
+	if err := app.RefreshRouter(); err != nil {
+		t.Fatal(err)
+	}
+	e.GET("/admin").Expect().Status(httptest.StatusForbidden)
+	e.GET("/admin").WithHeader("X-Scope", "admin").Expect().Status(httptest.StatusOK).
+		Body().Equal("replaced")
+}
@@ -0,0 +1,93 @@
+// black-box testing
+package router_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestNamedMiddleware(t *testing.T) {
+	app := iris.New()
+	app.RegisterMiddleware("useglobal1", firstUseGlobalHandler)
+	app.RegisterMiddleware("useglobal2", secondUseGlobalHandler)
+	app.RegisterMiddleware("use1", firstUseHandler)
+	app.RegisterMiddleware("use2", secondUseHandler)
+	app.RegisterMiddleware("done1", firstDoneHandler)
+	app.RegisterMiddleware("done2", secondDoneHandler)
+
+	app.UseNamed("use1")
+	app.DoneNamed("done1")
+	app.Get("/mypath", mainHandler)
+	app.Get("/users/{id:int}", mainHandler).UseNamed("use2")
+	app.Get("/except", mainHandler).UseNamed("use2").Except("use1", "useglobal2", "done1", "done2")
+	app.DoneNamed("done2")
+	app.UseGlobalNamed("useglobal1", "useglobal2")
+
+	e := httptest.New(t, app)
+	e.GET("/mypath").Expect().Status(httptest.StatusOK).
+		Body().Equal(firstUseGlobalResponse + secondUseGlobalResponse + firstUseResponse + mainResponse + firstDoneResponse + secondDoneResponse)
+	e.GET("/users/42").Expect().Status(httptest.StatusOK).Body().Equal(finalResponse)
+	e.GET("/users/notint").Expect().Status(httptest.StatusNotFound)
+	e.GET("/except").Expect().Status(httptest.StatusOK).
+		Body().Equal(firstUseGlobalResponse + secondUseResponse + mainResponse)
+}
+
+func TestNamedMiddlewareChain(t *testing.T) {
+	app := iris.New()
+	app.RegisterMiddleware("auth", firstUseHandler)
+	app.UseGlobal(firstUseGlobalHandler)
+
+	api := app.Party("/api")
+	api.UseNamed("auth")
+	users := api.Get("/users", mainHandler)
+	health := api.Get("/health", mainHandler).Except("auth")
+
+	mainHandlerName := context.HandlerName(mainHandler)
+	useGlobalHandlerName := context.HandlerName(firstUseGlobalHandler)
+
+	if expected, got := []string{useGlobalHandlerName, "auth", mainHandlerName}, users.Chain(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the chain %v but got %v", expected, got)
+	}
+
+	if expected, got := []string{useGlobalHandlerName, mainHandlerName}, health.Chain(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the chain %v but got %v", expected, got)
+	}
+
+	// the chain is the same after the build.
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "GET: /api/users -> " + useGlobalHandlerName + ", auth, " + mainHandlerName + " ("
+	if got := users.TraceChain(); !strings.HasPrefix(got, expected) {
+		t.Fatalf("expected the trace to start with %q but got %q", expected, got)
+	}
+
+	e := httptest.New(t, app)
+	e.GET("/api/users").Expect().Status(httptest.StatusOK).Body().Equal(firstUseGlobalResponse + firstUseResponse + mainResponse)
+	e.GET("/api/health").Expect().Status(httptest.StatusOK).Body().Equal(firstUseGlobalResponse + mainResponse)
+}
+
+func TestNamedMiddlewareNotRegistered(t *testing.T) {
+	app := iris.New()
+	app.RegisterMiddleware("auth", firstUseHandler)
+	app.RegisterMiddleware("auth", secondUseHandler)
+	app.UseNamed("autth")
+	app.Get("/", mainHandler).Except("audit")
+
+	err := app.Build()
+	if err == nil {
+		t.Fatalf("expected the not registered middleware to be reported")
+	}
+
+	for _, s := range []string{`middleware "auth" is already registered`, `middleware "autth" is not registered`, `middleware "audit" is not registered`} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected the error to contain %q but got: %v", s, err)
+		}
+	}
+}
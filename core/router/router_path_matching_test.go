@@ -0,0 +1,97 @@
+// black-box testing
+package router_test
+
+import (
+	"net/http"
+	stdhttptest "net/http/httptest"
+	"testing"
+
+	"github.com/kataras/iris"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterPathMatching(t *testing.T) {
+	app := iris.New()
+	products := app.Party("/products").PathMatching(iris.PathMatchCaseInsensitive)
+	products.Get("/{id}", writeRouteAndParams)
+	products.Get("/{id}/reviews/{file:path}", writeRouteAndParams)
+
+	app.Party("/été").PathMatching(iris.PathMatchCaseInsensitive).Get("/", writeRouteAndParams)
+	app.Party("/café").PathMatching(iris.PathMatchNormalize).Get("/", writeRouteAndParams)
+	app.Get("/èze", writeRouteAndParams)
+	app.Get("/about", writeRouteAndParams)
+
+	e := httptest.New(t, app)
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/products/abc", httptest.StatusOK, "/products/{id} id=abc"},
+		{"/Products/ABC", httptest.StatusOK, "/products/{id} id=ABC"},
+		{"/PRODUCTS/Abc/Reviews/A/b.txt", httptest.StatusOK, "/products/{id}/reviews/{file:path} id=Abc file=A/b.txt"},
+		{"/ÉTÉ", httptest.StatusOK, "/été"},
+		{"/èze", httptest.StatusOK, "/èze"},
+		{"/ÈZE", httptest.StatusNotFound, "Not Found"},
+		// "e" followed by the combining acute accent.
+		{"/cafe\u0301", httptest.StatusOK, "/café"},
+		{"/CAFE\u0301", httptest.StatusNotFound, "Not Found"},
+		{"/About", httptest.StatusNotFound, "Not Found"},
+	}
+
+	for _, tt := range tests {
+		e.GET(tt.path).Expect().Status(tt.status).Body().Equal(tt.body)
+	}
+}
+
+func TestRouterPathMatchingConfiguration(t *testing.T) {
+	app := iris.New()
+	app.Configure(iris.WithCaseInsensitivePaths, iris.WithPathNormalization)
+	app.Get("/about", writeRouteAndParams)
+	app.Party("/admin").PathMatching(iris.PathMatchExact).Get("/", writeRouteAndParams)
+	app.Party("/café").Get("/", writeRouteAndParams)
+
+	e := httptest.New(t, app)
+	e.GET("/About").Expect().Status(httptest.StatusOK).Body().Equal("/about")
+	e.GET("/CAFE\u0301").Expect().Status(httptest.StatusOK).Body().Equal("/café")
+	e.GET("/admin").Expect().Status(httptest.StatusOK).Body().Equal("/admin")
+	e.GET("/Admin").Expect().Status(httptest.StatusNotFound)
+}
+
+func TestRouterPathMatchingRedirect(t *testing.T) {
+	app := iris.New()
+	docs := app.Party("/docs").PathMatching(iris.PathMatchCaseInsensitive | iris.PathMatchRedirect)
+	docs.Get("/{page}", writeRouteAndParams)
+	docs.Post("/{page}/comments", writeRouteAndParams)
+	docs.Get("/files/{file:path}", writeRouteAndParams)
+
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, path string
+		status       int
+		location     string
+	}{
+		{"GET", "/docs/Intro", http.StatusOK, ""},
+		{"GET", "/Docs/Intro?lang=en", http.StatusMovedPermanently, "/docs/Intro?lang=en"},
+		{"POST", "/DOCS/Intro/Comments", http.StatusPermanentRedirect, "/docs/Intro/comments"},
+		{"GET", "/DOCS/FILES", http.StatusMovedPermanently, "/docs/files"},
+	}
+
+	for i, tt := range tests {
+		req := stdhttptest.NewRequest(tt.method, tt.path, nil)
+		rec := stdhttptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Fatalf("[%d] %s %s: expected status code %d but got %d", i, tt.method, tt.path, tt.status, rec.Code)
+		}
+
+		if got := rec.Header().Get("Location"); got != tt.location {
+			t.Fatalf("[%d] %s %s: expected location %q but got %q", i, tt.method, tt.path, tt.location, got)
+		}
+	}
+}
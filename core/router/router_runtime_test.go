@@ -0,0 +1,238 @@
+// black-box testing
+package router_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func writeText(text string) context.Handler {
+	return func(ctx context.Context) {
+		ctx.WriteString(text)
+	}
+}
+
+func TestRouterRemoveAndReplaceRoutes(t *testing.T) {
+	app := iris.New()
+	app.Get("/", writeText("index"))
+	app.Get("/about", writeText("about")).Name = "about"
+	users := app.Party("/users")
+	users.Get("/{id:int}", writeText("user"))
+	users.Post("/{id:int}", writeText("create user"))
+
+	e := httptest.New(t, app)
+	e.GET("/about").Expect().Status(httptest.StatusOK).Body().Equal("about")
+	e.GET("/users/42").Expect().Status(httptest.StatusOK).Body().Equal("user")
+
+	if !app.RemoveRoute("about") {
+		t.Fatalf("expected route 'about' to be removed")
+	}
+	if app.RemoveRoute("about") {
+		t.Fatalf("expected route 'about' to be already removed")
+	}
+	if !users.RemoveRouteByPath(iris.MethodPost, "/{id:int}") {
+		t.Fatalf("expected route 'POST /users/{id:int}' to be removed")
+	}
+	users.ReplaceRoute(iris.MethodGet, "/{id:int}", writeText("replaced user"))
+	app.ReplaceRoute(iris.MethodGet, "/contact", writeText("contact"))
+
+	// not refreshed yet.
+	e.GET("/about").Expect().Status(httptest.StatusOK).Body().Equal("about")
+
+	if err := app.RefreshRouter(); err != nil {
+		t.Fatal(err)
+	}
+
+	e.GET("/").Expect().Status(httptest.StatusOK).Body().Equal("index")
+	e.GET("/about").Expect().Status(httptest.StatusNotFound)
+	e.POST("/users/42").Expect().Status(httptest.StatusNotFound)
+	e.GET("/users/42").Expect().Status(httptest.StatusOK).Body().Equal("replaced user")
+	e.GET("/contact").Expect().Status(httptest.StatusOK).Body().Equal("contact")
+
+	if r := app.GetRoute("GET/users/{id:int}"); r == nil {
+		t.Fatalf("expected replaced route to keep its name")
+	}
+	if expected, got := 3, len(app.GetRoutes()); expected != got {
+		t.Fatalf("expected %d routes but got %d", expected, got)
+	}
+}
+
+func TestRouterRefreshWhileServing(t *testing.T) {
+	app := iris.New()
+	app.Get("/", writeText("index"))
+
+	e := httptest.New(t, app)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				e.GET("/").Expect().Status(httptest.StatusOK).Body().Equal("index")
+			}
+		}()
+	}
+
+	for j := 0; j < 20; j++ {
+		app.ReplaceRoute(iris.MethodGet, "/", writeText("index"))
+		app.ReplaceRoute(iris.MethodGet, "/other", writeText("other"))
+		if err := app.RefreshRouter(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wg.Wait()
+}
+
+func TestRouterReplaceRouteFromParent(t *testing.T) {
+	app := iris.New()
+	users := app.Party("/users")
+	users.Get("/{id:int}", writeText("user"))
+
+	// replaced by the parent, the child's Done should still reach the new route.
+	app.ReplaceRoute(iris.MethodGet, "/users/{id:int}", func(ctx context.Context) {
+		ctx.WriteString("replaced user")
+		ctx.Next()
+	})
+	users.Done(writeText(" done"))
+
+	e := httptest.New(t, app)
+	e.GET("/users/42").Expect().Status(httptest.StatusOK).Body().Equal("replaced user done")
+
+	if !users.RemoveRouteByPath(iris.MethodGet, "/{id:int}") {
+		t.Fatalf("expected the replaced route to be removed by the child")
+	}
+	if err := app.RefreshRouter(); err != nil {
+		t.Fatal(err)
+	}
+	e.GET("/users/42").Expect().Status(httptest.StatusNotFound)
+}
+
+func TestRouterRemoveAndReplaceRoutesByHost(t *testing.T) {
+	app := iris.New()
+	app.Get("/", writeText("index"))
+	app.Get("/about", writeText("about"))
+	shop := app.Host("shop.com")
+	shop.Get("/", writeText("shop"))
+	shop.Get("/about", writeText("shop about"))
+
+	e := httptest.New(t, app)
+
+	if !shop.RemoveRouteByPath(iris.MethodGet, "/") {
+		t.Fatalf("expected route 'GET shop.com/' to be removed")
+	}
+	if shop.RemoveRouteByPath(iris.MethodGet, "/") {
+		t.Fatalf("expected route 'GET shop.com/' to be already removed")
+	}
+	shop.ReplaceRoute(iris.MethodGet, "/about", writeText("replaced shop about"))
+
+	if err := app.RefreshRouter(); err != nil {
+		t.Fatal(err)
+	}
+
+	e.GET("/").WithURL("http://example.com").Expect().Status(httptest.StatusOK).Body().Equal("index")
+	e.GET("/").WithURL("http://shop.com").Expect().Status(httptest.StatusNotFound)
+	e.GET("/about").WithURL("http://example.com").Expect().Status(httptest.StatusOK).Body().Equal("about")
+	e.GET("/about").WithURL("http://shop.com").Expect().Status(httptest.StatusOK).
+		Body().Equal("replaced shop about")
+}
+
+func TestRouterServeAfterFailedBuild(t *testing.T) {
+	app := iris.New()
+	app.Get("/", writeText("index"))
+	app.Get("/users", writeText("users"))
+	app.Get("/users", writeText("users again")).Name = "duplicate"
+
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for routes with the same path")
+	}
+
+	// served without the routes of the failed build, instead of a panic.
+	e := httptest.New(t, app)
+	e.GET("/").Expect().Status(httptest.StatusNotFound)
+
+	app.RemoveRoute("duplicate")
+	if err := app.RefreshRouter(); err != nil {
+		t.Fatal(err)
+	}
+	e.GET("/users").Expect().Status(httptest.StatusOK).Body().Equal("users")
+
+	// a failed refresh keeps the previous routes.
+	app.Get("/users", writeText("users again"))
+	if err := app.RefreshRouter(); err == nil {
+		t.Fatalf("expected an error for routes with the same path")
+	}
+	e.GET("/").Expect().Status(httptest.StatusOK).Body().Equal("index")
+	e.GET("/users").Expect().Status(httptest.StatusOK).Body().Equal("users")
+}
+
+func TestRouterRefreshWhileReadingCurrentRoute(t *testing.T) {
+	app := iris.New()
+	app.Get("/", func(ctx context.Context) {
+		// reads the route's handlers.
+		if r, ok := ctx.GetCurrentRoute().(interface{ Trace() string }); ok && r.Trace() != "" {
+			ctx.WriteString("index")
+		}
+		ctx.Next()
+	})
+
+	e := httptest.New(t, app)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				e.GET("/").Expect().Status(httptest.StatusOK).Body().Equal("index")
+			}
+		}()
+	}
+
+	served := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(served)
+	}()
+
+	// the routes are re-built with new done handlers while they're served.
+	for {
+		select {
+		case <-served:
+			return
+		default:
+			app.Done(func(ctx context.Context) {})
+			if err := app.RefreshRouter(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestRouterBuildKeepsRegisteredRoutesHandlers(t *testing.T) {
+	app := iris.New()
+	app.Get("/", writeText("index")).Name = "index"
+	app.UseGlobal(func(ctx context.Context) { ctx.Next() })
+	app.Done(func(ctx context.Context) {})
+
+	httptest.New(t, app)
+
+	if expected, got := 3, len(app.GetRoute("index").Handlers); expected != got {
+		t.Fatalf("expected %d built handlers on the registered route but got %d", expected, got)
+	}
+
+	app.Done(func(ctx context.Context) {})
+	if err := app.RefreshRouter(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, got := 4, len(app.GetRoute("index").Handlers); expected != got {
+		t.Fatalf("expected %d built handlers on the registered route after refresh but got %d", expected, got)
+	}
+}
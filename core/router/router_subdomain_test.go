@@ -0,0 +1,100 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/router"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterDynamicSubdomain(t *testing.T) {
+	app := iris.New()
+
+	writeParams := func(ctx context.Context) {
+		ctx.Writef("%s %s %s", ctx.Params().Get("region"), ctx.Params().Get("tenant"), ctx.Params().Get("id"))
+	}
+
+	app.Subdomain("admin.").Get("/", writeText("admin"))
+
+	tenant := app.Subdomain("{tenant:alphabetical}.")
+	tenant.Get("/", writeParams).Name = "tenant"
+	tenant.Get("/users/{id:int}", writeParams).Name = "tenantUser"
+
+	regional := app.Subdomain("{region:alphabetical max(2)}.{tenant:alphabetical}.")
+	regional.Get("/", writeParams).Name = "regional"
+
+	app.Subdomain("{id:int}.").Get("/", func(ctx context.Context) {
+		id, _ := ctx.Params().GetInt("id")
+		ctx.Writef("%d", id+1)
+	})
+
+	app.Get("/", writeText("root"))
+
+	e := httptest.New(t, app)
+
+	e.GET("/").WithURL("http://example.com").Expect().Status(httptest.StatusOK).Body().Equal("root")
+	// static subdomains have priority over the dynamic ones.
+	e.GET("/").WithURL("http://admin.example.com").Expect().Status(httptest.StatusOK).Body().Equal("admin")
+	e.GET("/").WithURL("http://acme.example.com").Expect().Status(httptest.StatusOK).Body().Equal(" acme ")
+	e.GET("/users/42").WithURL("http://acme.example.com").Expect().Status(httptest.StatusOK).Body().Equal(" acme 42")
+	e.GET("/").WithURL("http://eu.acme.example.com").Expect().Status(httptest.StatusOK).Body().Equal("eu acme ")
+	e.GET("/").WithURL("http://42.example.com").Expect().Status(httptest.StatusOK).Body().Equal("43")
+	// not a valid region, served by the {tenant}.
+	e.GET("/").WithURL("http://asia.acme.example.com").Expect().Status(httptest.StatusOK).Body().Equal(" asia ")
+	// not a valid tenant, served by the root domain.
+	e.GET("/").WithURL("http://acme1.example.com").Expect().Status(httptest.StatusOK).Body().Equal("root")
+	e.GET("/users/42").WithURL("http://acme1.example.com").Expect().Status(httptest.StatusNotFound)
+
+	rv := router.NewRoutePathReverser(app, router.WithHost("example.com"), router.WithScheme("https"))
+	if expected, got := "https://acme.example.com/users/42", rv.URL("tenantUser", "acme", 42); expected != got {
+		t.Fatalf("expected url %s but got %s", expected, got)
+	}
+	if expected, got := "https://eu.acme.example.com/", rv.URL("regional", "eu", "acme"); expected != got {
+		t.Fatalf("expected url %s but got %s", expected, got)
+	}
+}
+
+func TestRouterDynamicSubdomainInvalid(t *testing.T) {
+	app := iris.New()
+	app.Subdomain("api-{tenant}.").Get("/", writeText("invalid"))
+
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected an error for a subdomain label which mixes static text and a parameter")
+	}
+}
+
+func TestRouterDynamicSubdomainErrorCode(t *testing.T) {
+	app := iris.New()
+	app.OnErrorCode(iris.StatusNotFound, writeText("root not found"))
+
+	tenant := app.Subdomain("{tenant:string}.")
+	tenant.Get("/", writeText("tenant"))
+	tenant.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.Writef("%s not found", ctx.Params().Get("tenant"))
+	})
+
+	e := httptest.New(t, app)
+
+	e.GET("/").WithURL("http://acme.example.com").Expect().Status(httptest.StatusOK).Body().Equal("tenant")
+	e.GET("/notfound").WithURL("http://acme.example.com").Expect().Status(httptest.StatusNotFound).
+		Body().Equal("acme not found")
+	e.GET("/notfound").WithURL("http://example.com").Expect().Status(httptest.StatusNotFound).
+		Body().Equal("root not found")
+}
+
+func TestRouterDynamicSubdomainNotFoundKeepsParamsEmpty(t *testing.T) {
+	app := iris.New()
+	app.Subdomain("{tenant:alphabetical}.").Get("/users/{id:int}", writeText("user"))
+	app.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.Writef("%d", ctx.Params().Len())
+	})
+
+	e := httptest.New(t, app)
+	e.GET("/users/42").WithURL("http://acme.example.com").Expect().Status(httptest.StatusOK).Body().Equal("user")
+	// the subdomain matches but the path doesn't, the tenant is not stored.
+	e.GET("/missing").WithURL("http://acme.example.com").Expect().Status(httptest.StatusNotFound).Body().Equal("0")
+}
@@ -0,0 +1,70 @@
+// black-box testing
+package router_test
+
+import (
+	"net/http"
+	stdhttptest "net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func TestRouterTrailingSlashPolicy(t *testing.T) {
+	app := iris.New()
+	app.Get("/about", writeText("about"))
+	app.Post("/about", writeText("posted about"))
+
+	api := app.Party("/api").TrailingSlash(iris.TrailingSlashMatch)
+	api.Get("/orders", writeText("orders"))
+	api.Post("/orders", writeText("created order"))
+
+	strict := app.Party("/strict").TrailingSlash(iris.TrailingSlashStrict)
+	strict.Get("/orders", writeText("strict orders"))
+
+	e := httptest.New(t, app)
+
+	// default, redirect.
+	for method, expectedStatusCode := range map[string]int{
+		http.MethodGet:  httptest.StatusMovedPermanently,
+		http.MethodPost: httptest.StatusPermanentRedirect,
+	} {
+		rec := stdhttptest.NewRecorder()
+		app.ServeHTTP(rec, stdhttptest.NewRequest(method, "/about/?q=1", nil))
+		if rec.Code != expectedStatusCode {
+			t.Fatalf("%s: expected status code %d but got %d", method, expectedStatusCode, rec.Code)
+		}
+		if expected, got := "/about?q=1", rec.Header().Get("Location"); expected != got {
+			t.Fatalf("%s: expected location %s but got %s", method, expected, got)
+		}
+	}
+	// the clients keep the method on 308.
+	e.GET("/about/").Expect().Status(httptest.StatusOK).Body().Equal("about")
+	e.POST("/about/").Expect().Status(httptest.StatusOK).Body().Equal("posted about")
+
+	// match both.
+	e.GET("/api/orders").Expect().Status(httptest.StatusOK).Body().Equal("orders")
+	e.GET("/api/orders/").Expect().Status(httptest.StatusOK).Body().Equal("orders")
+	e.POST("/api/orders/").Expect().Status(httptest.StatusOK).Body().Equal("created order")
+
+	// strict.
+	e.GET("/strict/orders").Expect().Status(httptest.StatusOK).Body().Equal("strict orders")
+	e.GET("/strict/orders/").Expect().Status(httptest.StatusNotFound)
+}
+
+func TestRouterTrailingSlashStrictKeepsParamsEmpty(t *testing.T) {
+	app := iris.New()
+	strict := app.Party("/strict").TrailingSlash(iris.TrailingSlashStrict)
+	strict.Get("/users/{id:int}", writeText("user"))
+	app.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.WriteString(strconv.Itoa(ctx.Params().Len()))
+	})
+
+	e := httptest.New(t, app)
+	e.GET("/strict/users/42").Expect().Status(httptest.StatusOK).Body().Equal("user")
+	// the not served route's params are not visible to the error code handler.
+	e.GET("/strict/users/42/").Expect().Status(httptest.StatusNotFound).Body().Equal("0")
+}
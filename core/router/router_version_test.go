@@ -0,0 +1,113 @@
+// black-box testing
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+
+	"github.com/kataras/iris/httptest"
+)
+
+func writeVersion(text string) context.Handler {
+	return func(ctx context.Context) {
+		ctx.Writef("%s %s", text, ctx.GetVersion())
+	}
+}
+
+func TestRouterVersioning(t *testing.T) {
+	app := iris.New()
+
+	api := app.Party("/api").DefaultVersion("1")
+
+	deprecatedSince := time.Date(2017, time.November, 1, 0, 0, 0, 0, time.UTC)
+	v1 := api.Version("1.0.0").Deprecate(deprecatedSince)
+	v1.Get("/users", writeVersion("users"))
+
+	api.Version("1.1.0").Get("/users", writeVersion("users"))
+
+	v2 := api.Version("2.0.0")
+	v2.Get("/users", writeVersion("users"))
+	v2.Post("/users", writeVersion("created user"))
+
+	api.Get("/unversioned", writeVersion("unversioned"))
+
+	e := httptest.New(t, app)
+
+	// default.
+	e.GET("/api/users").Expect().Status(httptest.StatusOK).Body().Equal("users 1.1.0")
+
+	e.GET("/api/users").WithHeader("Accept-Version", "2").Expect().
+		Status(httptest.StatusOK).Body().Equal("users 2.0.0")
+	e.GET("/api/users").WithHeader("Accept-Version", "^1").Expect().
+		Status(httptest.StatusOK).Body().Equal("users 1.1.0")
+	e.GET("/api/users").WithHeader("Accept-Version", "~1.0").Expect().
+		Status(httptest.StatusOK).Body().Equal("users 1.0.0")
+	e.GET("/api/users").WithHeader("Accept-Version", ">= 1.1, < 3 || 5.x").Expect().
+		Status(httptest.StatusOK).Body().Equal("users 2.0.0")
+	e.GET("/api/users").WithHeader("Accept", "application/vnd.api+json; version=1.1.0").Expect().
+		Status(httptest.StatusOK).Body().Equal("users 1.1.0")
+
+	r := e.GET("/api/users").WithHeader("Accept-Version", "1.0.0").Expect().Status(httptest.StatusOK)
+	r.Body().Equal("users 1.0.0")
+	r.Header("Deprecation").Equal("Wed, 01 Nov 2017 00:00:00 GMT")
+	r.Header("Vary").Equal("Accept-Version")
+
+	e.GET("/api/users").WithHeader("Accept-Version", "3").Expect().Status(httptest.StatusNotImplemented)
+	e.GET("/api/users").WithHeader("Accept-Version", "one").Expect().Status(httptest.StatusBadRequest)
+	// 1.x doesn't have a POST /users.
+	e.POST("/api/users").Expect().Status(httptest.StatusNotImplemented)
+	e.POST("/api/users").WithHeader("Accept-Version", "2.0.0").Expect().
+		Status(httptest.StatusOK).Body().Equal("created user 2.0.0")
+
+	e.GET("/api/unversioned").WithHeader("Accept-Version", "2").Expect().
+		Status(httptest.StatusOK).Body().Equal("unversioned ")
+}
+
+func TestRouterVersioningCurrentRoute(t *testing.T) {
+	app := iris.New()
+
+	writeVersionMeta := func(ctx context.Context) {
+		r := ctx.GetCurrentRoute()
+		ctx.Writef("%s %s", r.Meta().GetString("version"), r.Name())
+	}
+
+	app.Version("1.0.0").Get("/users", writeVersionMeta).SetMeta("version", "v1")
+	app.Version("2.0.0").Get("/users", writeVersionMeta).SetMeta("version", "v2")
+
+	app.Get("/articles", writeVersionMeta).Produce("application/json").SetMeta("version", "json")
+	app.Get("/articles", writeVersionMeta).Produce("text/html").SetMeta("version", "html")
+
+	e := httptest.New(t, app)
+
+	e.GET("/users").WithHeader("Accept-Version", "1").Expect().Status(httptest.StatusOK).
+		Body().Equal("v1 GET/users@1.0.0")
+	e.GET("/users").WithHeader("Accept-Version", "2").Expect().Status(httptest.StatusOK).
+		Body().Equal("v2 GET/users@2.0.0")
+	e.GET("/articles").WithHeader("Accept", "application/json").Expect().Status(httptest.StatusOK).
+		Body().Equal("json GET/articles produces=application/json")
+	e.GET("/articles").WithHeader("Accept", "text/html").Expect().Status(httptest.StatusOK).
+		Body().Equal("html GET/articles produces=text/html")
+}
+
+func TestRouterVersioningReplaceRoute(t *testing.T) {
+	app := iris.New()
+	v1 := app.Version("1.0.0")
+	v1.Get("/users", writeVersion("users"))
+	v2 := app.Version("2.0.0")
+	v2.Get("/users", writeVersion("users"))
+
+	v2.ReplaceRoute(iris.MethodGet, "/users", writeVersion("replaced users"))
+	if v1.RemoveRouteByPath(iris.MethodPost, "/users") {
+		t.Fatalf("expected a POST /users of the 1.0.0 version to be missing")
+	}
+
+	e := httptest.New(t, app)
+
+	e.GET("/users").WithHeader("Accept-Version", "1").Expect().Status(httptest.StatusOK).
+		Body().Equal("users 1.0.0")
+	e.GET("/users").WithHeader("Accept-Version", "2").Expect().Status(httptest.StatusOK).
+		Body().Equal("replaced users 2.0.0")
+}
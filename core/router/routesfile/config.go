@@ -0,0 +1,88 @@
+package routesfile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/kataras/iris/core/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Routes is the contents of a routes file.
+//
+// A YAML example:
+//
+//	Routes:
+//	  - Path: /assets
+//	    Dir: ./public
+//	    Headers:
+//	      Cache-Control: public, max-age=86400
+//	  - Path: /app
+//	    Embedded: app
+//	    Dir: ./dist
+//	  - Path: /api
+//	    Proxy: http://localhost:8080
+//	  - Path: /old-docs
+//	    Redirect: https://docs.mydomain.com
+//	    StatusCode: 301
+type Routes struct {
+	Routes []Route `json:"routes,omitempty" yaml:"Routes" toml:"Routes"`
+}
+
+// Route describes a route, or the routes, that a routes file's entry registers.
+// One of the `Dir`, `Embedded`, `Proxy` or `Redirect` fields should be set.
+type Route struct {
+	// Method is the http method of a redirect, defaults to "GET",
+	// and of a proxy, defaults to all of the methods.
+	// The static files are served by "GET" and "HEAD".
+	Method string `json:"method,omitempty" yaml:"Method" toml:"Method"`
+	// Path is the request path, the static files, the embedded files
+	// and the proxy are serving the path and all of its sub paths.
+	Path string `json:"path" yaml:"Path" toml:"Path"`
+
+	// Dir is the system directory of the static files that the `Path` serves, see `Party#StaticWeb`,
+	// or the virtual directory of the embedded files, see `Party#StaticEmbedded`.
+	Dir string `json:"dir,omitempty" yaml:"Dir" toml:"Dir"`
+	// Embedded is the name of the embedded files, i.e go-bindata's ones, that the `Path` serves,
+	// they should be registered through the `Loader#Embedded`.
+	Embedded string `json:"embedded,omitempty" yaml:"Embedded" toml:"Embedded"`
+	// Proxy is the URL of the target server that the requests are forwarded to, see `host#ProxyHandler`,
+	// the request path is appended to the target's path.
+	Proxy string `json:"proxy,omitempty" yaml:"Proxy" toml:"Proxy"`
+	// StripPrefix removes the `Path` from the request path before it's forwarded to the `Proxy`.
+	StripPrefix bool `json:"stripPrefix,omitempty" yaml:"StripPrefix" toml:"StripPrefix"`
+	// Redirect is the URL that the `Path` redirects to.
+	Redirect string `json:"redirect,omitempty" yaml:"Redirect" toml:"Redirect"`
+	// StatusCode is the status code of the `Redirect`, defaults to 302.
+	StatusCode int `json:"statusCode,omitempty" yaml:"StatusCode" toml:"StatusCode"`
+
+	// Headers are the response headers that are sent by the route, i.e "Cache-Control".
+	Headers map[string]string `json:"headers,omitempty" yaml:"Headers" toml:"Headers"`
+}
+
+var errRoutesDecode = errors.New("failed to decode the routes file: %v")
+
+// Parse reads the routes file, it's decoded as TOML if
+// the "filename" has the ".toml" or ".tml" extension, otherwise as YAML.
+func Parse(filename string) (Routes, error) {
+	var r Routes
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return r, errRoutesDecode.Format(err)
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".toml", ".tml":
+		_, err = toml.Decode(string(data), &r)
+	default:
+		err = yaml.Unmarshal(data, &r)
+	}
+
+	if err != nil {
+		return r, errRoutesDecode.Format(err)
+	}
+	return r, nil
+}
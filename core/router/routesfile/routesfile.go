@@ -0,0 +1,282 @@
+// Package routesfile registers routes that are declared in a YAML or TOML file:
+// static directories, embedded files, reverse proxies and redirects, with their response headers.
+//
+// Usage:
+//
+//	loader := routesfile.New(app.APIBuilder).Embedded("app", Asset, AssetNames)
+//	if err := loader.Load("./routes.yml"); err != nil {
+//		panic(err)
+//	}
+//	app.Run(iris.Addr(":8080"))
+//
+// And to reload the file at serve-time, i.e on SIGHUP:
+//
+//	if err := loader.Reload(app); err != nil {
+//		app.Logger().Warn(err)
+//	}
+package routesfile
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/errors"
+	"github.com/kataras/iris/core/handlerconv"
+	"github.com/kataras/iris/core/host"
+	"github.com/kataras/iris/core/router"
+)
+
+// Loader registers the routes of a routes file to an APIBuilder,
+// the routes that were registered by a previous load are removed.
+type Loader struct {
+	api *router.APIBuilder
+
+	mu       sync.Mutex
+	filename string
+	embedded map[string]embeddedFiles
+	// the routes of the last load and their declarations.
+	routes  []*router.Route
+	applied Routes
+}
+
+type embeddedFiles struct {
+	assetFn func(name string) ([]byte, error)
+	namesFn func() []string
+}
+
+// New returns a new Loader which registers the routes to the "api",
+// i.e the `app.APIBuilder` or a Party, as `Party("/admin").(*router.APIBuilder)`.
+func New(api *router.APIBuilder) *Loader {
+	return &Loader{
+		api:      api,
+		embedded: make(map[string]embeddedFiles),
+	}
+}
+
+// Embedded registers embedded files, i.e go-bindata's `Asset` and `AssetNames`, by a "name",
+// the routes file's entries are referring to them through the `Route#Embedded` field.
+// Returns itself.
+func (l *Loader) Embedded(name string, assetFn func(name string) ([]byte, error), namesFn func() []string) *Loader {
+	l.mu.Lock()
+	l.embedded[name] = embeddedFiles{assetFn, namesFn}
+	l.mu.Unlock()
+	return l
+}
+
+// Load parses the routes file and registers its routes, see `Parse` and `Apply`.
+func (l *Loader) Load(filename string) error {
+	routes, err := Parse(filename)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err = l.apply(routes); err != nil {
+		return err
+	}
+
+	l.filename = filename
+	return nil
+}
+
+var errRollback = errors.New("%v, the previous routes could not be restored: %v")
+
+// Reload loads the last loaded routes file again and refreshes the "router",
+// i.e the `Application`, in order to serve the new routes.
+// If the file is not valid, or the router can't be refreshed with its routes,
+// then the previous routes are kept.
+func (l *Loader) Reload(router interface {
+	RefreshRouter() error
+}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.filename == "" {
+		return errors.New("routes file was not loaded")
+	}
+
+	routes, err := Parse(l.filename)
+	if err != nil {
+		return err
+	}
+
+	previous := l.applied
+	if err = l.apply(routes); err != nil {
+		return err
+	}
+
+	if err = router.RefreshRouter(); err != nil {
+		// i.e a duplicated path, the previous routes were valid and they are still served.
+		l.replace(previous)
+		if rerr := router.RefreshRouter(); rerr != nil {
+			return errRollback.Format(err, rerr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Apply validates the "routes" and registers them, the routes of a previous load are removed first.
+// If a route is not valid then nothing is changed and all of the errors are returned.
+//
+// The router should be refreshed, through the `RefreshRouter`, in order to serve the new routes
+// if the application is already running.
+func (l *Loader) Apply(routes Routes) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.apply(routes)
+}
+
+func (l *Loader) apply(routes Routes) error {
+	rp := errors.NewReporter()
+	for i, r := range routes.Routes {
+		if err := l.validate(r); err != nil {
+			rp.Add("routes[%d] %s: %v", i, r.Path, err)
+		}
+	}
+
+	if err := rp.Return(); err != nil {
+		return err
+	}
+
+	l.replace(routes)
+	return nil
+}
+
+// replace removes the routes of the previous load, by their identity
+// because a route of the application may have the same name, and registers the "routes".
+func (l *Loader) replace(routes Routes) {
+	l.api.RemoveRoutes(l.routes...)
+	l.routes = nil
+
+	for _, r := range routes.Routes {
+		l.routes = append(l.routes, l.register(r)...)
+	}
+	l.applied = routes
+}
+
+func (l *Loader) validate(r Route) error {
+	if r.Path == "" || r.Path[0] != '/' {
+		return errors.New("path should start with a slash")
+	}
+
+	kinds := 0
+	for _, v := range []string{r.Embedded, r.Proxy, r.Redirect} {
+		if v != "" {
+			kinds++
+		}
+	}
+	if r.Dir != "" && r.Embedded == "" {
+		kinds++
+	}
+	if kinds != 1 {
+		return errors.New("one of the dir, embedded, proxy or redirect should be set")
+	}
+
+	switch {
+	case r.Embedded != "":
+		if _, ok := l.embedded[r.Embedded]; !ok {
+			return errors.New("embedded files %q are not registered").Format(r.Embedded)
+		}
+	case r.Dir != "":
+		if info, err := os.Stat(r.Dir); err != nil || !info.IsDir() {
+			return errors.New("directory %q does not exist").Format(r.Dir)
+		}
+	case r.Proxy != "":
+		if u, err := url.Parse(r.Proxy); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("invalid proxy URL %q").Format(r.Proxy)
+		}
+	case r.StatusCode != 0 && (r.StatusCode < 300 || r.StatusCode > 399):
+		return errors.New("invalid redirect status code %d").Format(r.StatusCode)
+	}
+
+	return nil
+}
+
+// register registers the route(s) of the "r" and returns them.
+func (l *Loader) register(r Route) []*router.Route {
+	var middleware context.Handlers
+	if len(r.Headers) > 0 {
+		middleware = append(middleware, headers(r.Headers))
+	}
+
+	p := l.api.Party("/", middleware...).(*router.APIBuilder)
+	existing := p.GetRoutes()
+
+	switch {
+	case r.Embedded != "":
+		files := l.embedded[r.Embedded]
+		p.StaticEmbedded(r.Path, r.Dir, files.assetFn, files.namesFn)
+	case r.Dir != "":
+		p.StaticWeb(r.Path, r.Dir)
+	case r.Proxy != "":
+		target, _ := url.Parse(r.Proxy)
+		h := handlerconv.FromStd(host.ProxyHandler(target))
+		if r.StripPrefix {
+			h = stripPrefix(h)
+		}
+
+		path := strings.TrimSuffix(r.Path, "/") + "/{proxyPath:path}"
+		if r.Method == "" {
+			p.Any(path, h)
+		} else {
+			p.Handle(r.Method, path, h)
+		}
+	default:
+		method := r.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		target, statusCode := r.Redirect, r.StatusCode
+		p.Handle(method, r.Path, func(ctx context.Context) {
+			ctx.Redirect(target, statusCode)
+		})
+	}
+
+	return newRoutes(existing, p.GetRoutes())
+}
+
+// stripPrefix forwards the request path without the route's path,
+// which is the proxy's path parameter.
+func stripPrefix(h context.Handler) context.Handler {
+	return func(ctx context.Context) {
+		ctx.Request().URL.Path = "/" + ctx.Params().Get("proxyPath")
+		ctx.Request().URL.RawPath = ""
+		h(ctx)
+	}
+}
+
+// headers returns a middleware which sets the response "headers".
+func headers(headers map[string]string) context.Handler {
+	return func(ctx context.Context) {
+		for key, value := range headers {
+			ctx.Header(key, value)
+		}
+		ctx.Next()
+	}
+}
+
+// newRoutes returns the routes that are not part of the "existing" ones.
+func newRoutes(existing, routes []*router.Route) []*router.Route {
+	registered := make(map[*router.Route]bool, len(existing))
+	for _, r := range existing {
+		registered[r] = true
+	}
+
+	var added []*router.Route
+	for _, r := range routes {
+		if !registered[r] {
+			added = append(added, r)
+		}
+	}
+	return added
+}
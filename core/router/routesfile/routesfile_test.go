@@ -0,0 +1,225 @@
+// black-box testing
+package routesfile_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	stdhttptest "net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/core/router/routesfile"
+	"github.com/kataras/iris/httptest"
+)
+
+func writeFile(t *testing.T, filename, contents string) {
+	if err := ioutil.WriteFile(filename, []byte(contents), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func serve(app *iris.Application, path string) *stdhttptest.ResponseRecorder {
+	rec := stdhttptest.NewRecorder()
+	app.ServeHTTP(rec, stdhttptest.NewRequest("GET", path, nil))
+	return rec
+}
+
+func TestRoutesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routesfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	public := filepath.Join(dir, "public")
+	if err = os.Mkdir(public, os.FileMode(0755)); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(public, "hello.txt"), "hello")
+
+	backend := stdhttptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("backend " + r.Method + " " + r.URL.Path))
+	}))
+	defer backend.Close()
+
+	filename := filepath.Join(dir, "routes.yml")
+	writeFile(t, filename, `
+Routes:
+  - Path: /assets
+    Dir: `+public+`
+    Headers:
+      Cache-Control: public, max-age=86400
+  - Path: /app
+    Embedded: app
+    Dir: ./dist
+  - Path: /api
+    Proxy: `+backend.URL+`/v1
+  - Path: /legacy
+    Proxy: `+backend.URL+`
+    StripPrefix: true
+    Method: POST
+  - Path: /old-docs
+    Redirect: https://docs.mydomain.com
+    StatusCode: 301
+`)
+
+	app := iris.New()
+	loader := routesfile.New(app.APIBuilder).Embedded("app", func(name string) ([]byte, error) {
+		if name == "dist/app.js" {
+			return []byte("app.js"), nil
+		}
+		return nil, os.ErrNotExist
+	}, func() []string {
+		return []string{"dist/app.js"}
+	})
+
+	if err = loader.Load(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	e := httptest.New(t, app)
+	e.GET("/assets/hello.txt").Expect().Status(httptest.StatusOK).
+		Header("Cache-Control").Equal("public, max-age=86400")
+	e.GET("/assets/hello.txt").Expect().Body().Equal("hello")
+	e.GET("/app/app.js").Expect().Status(httptest.StatusOK).Body().Equal("app.js")
+	e.DELETE("/api/users/42").Expect().Status(httptest.StatusOK).Body().Equal("backend DELETE /v1/api/users/42")
+	e.POST("/legacy/orders").Expect().Status(httptest.StatusOK).Body().Equal("backend POST /orders")
+	e.GET("/legacy/orders").Expect().Status(httptest.StatusNotFound)
+	rec := serve(app, "/old-docs")
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "https://docs.mydomain.com" {
+		t.Fatalf("expected a redirect but got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// reload with a different file's contents.
+	writeFile(t, filename, `
+Routes:
+  - Path: /api
+    Proxy: `+backend.URL+`/v2
+`)
+	if err = loader.Reload(app); err != nil {
+		t.Fatal(err)
+	}
+
+	e.GET("/api/users").Expect().Status(httptest.StatusOK).Body().Equal("backend GET /v2/api/users")
+	e.GET("/assets/hello.txt").Expect().Status(httptest.StatusNotFound)
+	e.GET("/old-docs").Expect().Status(httptest.StatusNotFound)
+
+	// an invalid file keeps the previous routes.
+	writeFile(t, filename, `
+Routes:
+  - Path: /api
+    Proxy: `+backend.URL+`/v3
+  - Path: /static
+    Dir: `+filepath.Join(dir, "missing")+`
+  - Path: /both
+    Redirect: /
+    Proxy: `+backend.URL+`
+`)
+	err = loader.Reload(app)
+	if err == nil {
+		t.Fatalf("expected the invalid routes to be reported")
+	}
+	for _, s := range []string{"routes[1] /static: directory", "routes[2] /both: one of the dir, embedded, proxy or redirect should be set"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected the error to contain %q but got: %v", s, err)
+		}
+	}
+
+	e.GET("/api/users").Expect().Status(httptest.StatusOK).Body().Equal("backend GET /v2/api/users")
+}
+
+func TestRoutesFileReloadRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routesfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	app := iris.New()
+	// same name as the file's route, it should not be removed by a reload.
+	app.Get("/docs", func(ctx iris.Context) { ctx.WriteString("docs") }).Name = "GET/old-docs"
+
+	filename := filepath.Join(dir, "routes.yml")
+	writeFile(t, filename, `
+Routes:
+  - Path: /old-docs
+    Redirect: /docs
+`)
+
+	loader := routesfile.New(app.APIBuilder)
+	if err = loader.Load(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	e := httptest.New(t, app)
+
+	if err = loader.Reload(app); err != nil {
+		t.Fatal(err)
+	}
+	e.GET("/docs").Expect().Status(httptest.StatusOK).Body().Equal("docs")
+	if rec := serve(app, "/old-docs"); rec.Code != http.StatusFound {
+		t.Fatalf("expected a redirect but got %d", rec.Code)
+	}
+
+	// valid file but its routes can't be served, the previous routes are restored.
+	writeFile(t, filename, `
+Routes:
+  - Path: /docs
+    Redirect: /
+`)
+	if err = loader.Reload(app); err == nil {
+		t.Fatalf("expected an error for the duplicated path")
+	}
+
+	e.GET("/docs").Expect().Status(httptest.StatusOK).Body().Equal("docs")
+	if rec := serve(app, "/old-docs"); rec.Code != http.StatusFound {
+		t.Fatalf("expected the previous redirect but got %d", rec.Code)
+	}
+	if expected, got := 2, len(app.GetRoutes()); expected != got {
+		t.Fatalf("expected %d routes but got %d", expected, got)
+	}
+}
+
+func TestRoutesFileTOML(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routesfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "routes.toml")
+	writeFile(t, filename, `
+[[Routes]]
+Path = "/home"
+Redirect = "/"
+
+[Routes.Headers]
+X-Edge = "1"
+`)
+
+	routes, err := routesfile.Parse(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(routes.Routes) != 1 || routes.Routes[0].Redirect != "/" || routes.Routes[0].Headers["X-Edge"] != "1" {
+		t.Fatalf("unexpected routes: %#v", routes)
+	}
+
+	app := iris.New()
+	if err = routesfile.New(app.APIBuilder).Apply(routes); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = app.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(app, "/home")
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/" || rec.Header().Get("X-Edge") != "1" {
+		t.Fatalf("expected a redirect with the X-Edge header but got %d %v", rec.Code, rec.Header())
+	}
+}
@@ -2,9 +2,12 @@ package router
 
 import (
 	"net/http" // just for status codes
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/router/macro"
 )
 
 // ErrorCodeHandler is the entry
@@ -71,8 +74,24 @@ func (ch *ErrorCodeHandler) updateHandlers(handlers context.Handlers) {
 // User of this struct can register, get
 // a status code handler based on a status code or
 // fire based on a receiver context.
+//
+// Each Party can have its own error code handlers, see `Scope`.
 type ErrorCodeHandlers struct {
 	handlers []*ErrorCodeHandler
+
+	// the host, the subdomain and the path segments of the Party
+	// that these handlers are scoped to, empty for the root ones.
+	host      string
+	subdomain string
+	segments  []string
+	// the parsed subdomain, nil if the subdomain has no parameters, i.e "{tenant}.".
+	subdomainTmpl *subdomainTemplate
+	// root is nil for the root error code handlers.
+	root *ErrorCodeHandlers
+	// the scoped error code handlers of the parties,
+	// the most specific first, filled on the root only.
+	scopes []*ErrorCodeHandlers
+	mu     sync.RWMutex
 }
 
 func defaultErrorCodeHandlers() *ErrorCodeHandlers {
@@ -138,8 +157,144 @@ func (s *ErrorCodeHandlers) Register(statusCode int, handlers ...context.Handler
 	return h
 }
 
+// Scope returns the error code handlers of a Party based on its "host", "subdomain" and "path",
+// the same instance is returned for the same host, subdomain and path.
+//
+// The root's `Fire` fires the handler of the most specific scope
+// which matches the request's host, subdomain and path,
+// if that scope has no handler registered for the status code
+// then the handler of its parent (the next matching scope) is fired instead, and so on.
+func (s *ErrorCodeHandlers) Scope(host, subdomain, path string) *ErrorCodeHandlers {
+	return s.scope(host, subdomain, path, nil)
+}
+
+// scope same as `Scope` but the parameters of a dynamic subdomain
+// are parsed based on the "macros", the default ones are used if nil.
+func (s *ErrorCodeHandlers) scope(host, subdomain, path string, macros *macro.Map) *ErrorCodeHandlers {
+	root := s.getRoot()
+	segments := splitPathSegments(path)
+	if host == "" && subdomain == "" && len(segments) == 0 {
+		return root
+	}
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	for _, scope := range root.scopes {
+		if scope.host == host && scope.subdomain == subdomain && strings.Join(scope.segments, "/") == strings.Join(segments, "/") {
+			return scope
+		}
+	}
+
+	scope := &ErrorCodeHandlers{
+		host:      host,
+		subdomain: subdomain,
+		segments:  segments,
+		root:      root,
+	}
+
+	if isDynamicSubdomain(subdomain) {
+		if macros == nil {
+			macros = defaultMacros()
+		}
+		// an invalid subdomain is reported by its routes.
+		scope.subdomainTmpl, _ = parseSubdomain(subdomain, macros)
+	}
+
+	root.scopes = append(root.scopes, scope)
+	// hosts first, then subdomains, then the deepest paths.
+	sort.SliceStable(root.scopes, func(i, j int) bool {
+		a, b := root.scopes[i], root.scopes[j]
+		if (a.host != "") != (b.host != "") {
+			return a.host != ""
+		}
+		if (a.subdomain != "") != (b.subdomain != "") {
+			return a.subdomain != ""
+		}
+		return len(a.segments) > len(b.segments)
+	})
+
+	return scope
+}
+
+func (s *ErrorCodeHandlers) getRoot() *ErrorCodeHandlers {
+	if s.root != nil {
+		return s.root
+	}
+	return s
+}
+
+// matches reports whether this scope can handle the request's host, subdomain and path.
+func (s *ErrorCodeHandlers) matches(ctx context.Context) bool {
+	if s.host != "" && !matchHost(s.host, requestHostname(ctx)) {
+		return false
+	}
+
+	if s.subdomainTmpl != nil {
+		// the same way as the router matches the dynamic subdomains.
+		if !s.subdomainTmpl.match(ctx) {
+			return false
+		}
+	} else if s.subdomain != "" && !canHandleSubdomain(ctx, s.subdomain) {
+		return false
+	}
+
+	path := ctx.Path()
+	for _, segment := range s.segments {
+		path = strings.TrimLeft(path, "/")
+		if path == "" {
+			return false
+		}
+
+		if segment[0] == WildcardParamStart[0] || strings.HasSuffix(segment, ":path}") {
+			return true // matches the rest of the path.
+		}
+
+		part := path
+		if end := strings.IndexByte(path, '/'); end != -1 {
+			part = path[:end]
+		}
+
+		// dynamic segments, i.e {id:int}, match any value.
+		if segment[0] != '{' && segment[0] != ParamStart[0] && segment != part {
+			return false
+		}
+
+		path = path[len(part):]
+	}
+
+	return true
+}
+
+// resolve returns the handler of the most specific scope
+// (or the root itself) which matches the request and has registered the "statusCode".
+func (s *ErrorCodeHandlers) resolve(ctx context.Context, statusCode int) *ErrorCodeHandler {
+	s.mu.RLock()
+	for _, scope := range s.scopes {
+		if scope.matches(ctx) {
+			if ch := scope.Get(statusCode); ch != nil {
+				s.mu.RUnlock()
+				return ch
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	return s.Get(statusCode)
+}
+
+func splitPathSegments(path string) (segments []string) {
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return
+}
+
 // Fire executes an error http status code handler
-// based on the context's status code.
+// based on the context's status code,
+// the handler of the most specific Party (see `Scope`) is preferred.
 //
 // If a handler is not already registered,
 // then it creates & registers a new trivial handler on the-fly.
@@ -148,9 +303,11 @@ func (s *ErrorCodeHandlers) Fire(ctx context.Context) {
 	if statusCode < 400 {
 		return
 	}
-	ch := s.Get(statusCode)
+
+	root := s.getRoot()
+	ch := root.resolve(ctx, statusCode)
 	if ch == nil {
-		ch = s.Register(statusCode, statusText(statusCode))
+		ch = root.Register(statusCode, statusText(statusCode))
 	}
 	ch.Fire(ctx)
 }
@@ -71,3 +71,51 @@ func checkAndClearBuf(t *testing.T, buff *bytes.Buffer, expected string) {
 
 	buff.Reset()
 }
+
+func TestPartyOnErrorCode(t *testing.T) {
+	app := iris.New()
+	app.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.HTML("<h1>Not Found</h1>")
+	})
+	app.OnErrorCode(iris.StatusInternalServerError, defaultErrHandler)
+
+	api := app.Party("/api")
+	api.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.JSON(context.Map{"error": "not found"})
+	})
+	api.Get("/fail", func(ctx context.Context) {
+		ctx.StatusCode(iris.StatusInternalServerError)
+	})
+
+	users := api.Party("/users/{id:int}")
+	users.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.WriteString("user not found")
+	})
+
+	// registered before its parent's scope.
+	v2 := app.Party("/v2/api")
+	v2.OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.WriteString("v2 not found")
+	})
+	app.Party("/v2").OnErrorCode(iris.StatusNotFound, func(ctx context.Context) {
+		ctx.WriteString("v2 root not found")
+	})
+
+	e := httptest.New(t, app)
+
+	e.GET("/notfound").Expect().Status(iris.StatusNotFound).
+		Body().Equal("<h1>Not Found</h1>")
+	e.GET("/api/notfound").Expect().Status(iris.StatusNotFound).
+		JSON().Object().ValueEqual("error", "not found")
+	e.GET("/apinotfound").Expect().Status(iris.StatusNotFound).
+		Body().Equal("<h1>Not Found</h1>")
+	// fallback to the parent's (root) handler.
+	e.GET("/api/fail").Expect().Status(iris.StatusInternalServerError).
+		Body().Equal(http.StatusText(iris.StatusInternalServerError))
+	e.GET("/api/users/42/posts").Expect().Status(iris.StatusNotFound).
+		Body().Equal("user not found")
+	e.GET("/v2/api/notfound").Expect().Status(iris.StatusNotFound).
+		Body().Equal("v2 not found")
+	e.GET("/v2/notfound").Expect().Status(iris.StatusNotFound).
+		Body().Equal("v2 root not found")
+}
@@ -0,0 +1,159 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/netutil"
+	"github.com/kataras/iris/core/router/macro"
+	"github.com/kataras/iris/core/router/macro/interpreter/ast"
+)
+
+// subdomainTemplate is a parsed dynamic subdomain,
+// its labels can be static or macro parameters, i.e "{region}.{tenant:alphabetical}.".
+type subdomainTemplate struct {
+	// the labels by order, the static ones have a nil param.
+	labels []subdomainLabel
+}
+
+type subdomainLabel struct {
+	static string
+	param  *macro.TemplateParam
+}
+
+// isDynamicSubdomain returns true if the "subdomain" contains macro parameters, i.e "{tenant}.".
+func isDynamicSubdomain(subdomain string) bool {
+	return strings.IndexByte(subdomain, '{') != -1
+}
+
+// parseSubdomain parses a dynamic subdomain, i.e "{region}.{tenant:alphabetical}.",
+// each label can be static or a single parameter with the same syntax as the path's parameters,
+// the "path" parameter type is not allowed.
+func parseSubdomain(subdomain string, macros *macro.Map) (*subdomainTemplate, error) {
+	t := new(subdomainTemplate)
+
+	for _, label := range strings.Split(strings.TrimSuffix(subdomain, "."), ".") {
+		if strings.IndexByte(label, '{') == -1 {
+			t.labels = append(t.labels, subdomainLabel{static: label})
+			continue
+		}
+
+		if label[0] != '{' || label[len(label)-1] != '}' {
+			return nil, fmt.Errorf("subdomain label should be static or a single parameter: %s", label)
+		}
+
+		tmpl, err := macro.Parse("/"+label, macros)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tmpl.Params) != 1 || tmpl.Params[0].Type == ast.ParamTypePath {
+			return nil, fmt.Errorf("invalid subdomain parameter: %s", label)
+		}
+
+		t.labels = append(t.labels, subdomainLabel{param: &tmpl.Params[0]})
+	}
+
+	return t, nil
+}
+
+// paramNames returns the names of the subdomain's parameters, by order.
+func (t *subdomainTemplate) paramNames() (names []string) {
+	for _, l := range t.labels {
+		if l.param != nil {
+			names = append(names, l.param.Name)
+		}
+	}
+	return
+}
+
+// match same as `matches` but it stores the subdomain's parameters values
+// to the context's params on match.
+func (t *subdomainTemplate) match(ctx context.Context) bool {
+	if !t.matches(ctx) {
+		return false
+	}
+
+	t.storeParams(ctx)
+	return true
+}
+
+// matches reports whether the request's host starts with the subdomain's labels,
+// the context's params are not modified, see `storeParams`.
+func (t *subdomainTemplate) matches(ctx context.Context) bool {
+	host := ctx.Host()
+	if netutil.IsLoopbackSubdomain(host) {
+		return false
+	}
+
+	rest := host
+	for _, l := range t.labels {
+		dotIdx := strings.IndexByte(rest, '.')
+		if dotIdx <= 0 {
+			return false
+		}
+
+		value := rest[:dotIdx]
+		if l.param == nil && value != l.static || l.param != nil && !l.param.Eval(value) {
+			return false
+		}
+
+		rest = rest[dotIdx+1:]
+	}
+
+	if rest == "" {
+		return false // the host is a subdomain, i.e "tenant.localhost".
+	}
+
+	// the rest of the host should be the server's host, if known, i.e "{region}.{tenant}." should not match
+	// the "acme.mydomain.com" neither the "api.eu.acme.mydomain.com" hosts of the "mydomain.com" server.
+	// If not known then the rest should be a domain, i.e "example.com" is not a subdomain of the "com".
+	if vhost := ctx.Application().ConfigurationReadOnly().GetVHost(); vhost != "" {
+		if rest != vhost {
+			return false
+		}
+	} else if strings.IndexByte(rest, '.') == -1 && !strings.HasPrefix(rest, "localhost") {
+		return false
+	}
+
+	return true
+}
+
+// storeParams stores the subdomain's parameters values of a host
+// that `matches` to the context's params.
+func (t *subdomainTemplate) storeParams(ctx context.Context) {
+	rest := ctx.Host()
+	for _, l := range t.labels {
+		dotIdx := strings.IndexByte(rest, '.')
+		if l.param != nil {
+			value := rest[:dotIdx]
+			ctx.Params().Set(l.param.Name, value)
+			if l.param.Converter != nil {
+				if v, err := l.param.Converter(value); err == nil {
+					ctx.Params().SetValue(l.param.Name, v)
+				}
+			}
+		}
+		rest = rest[dotIdx+1:]
+	}
+}
+
+// resolve returns the subdomain with its parameters replaced by the "args", by order,
+// and the rest of the arguments.
+func (t *subdomainTemplate) resolve(args []string) (string, []string) {
+	labels := make([]string, len(t.labels))
+	for i, l := range t.labels {
+		if l.param == nil {
+			labels[i] = l.static
+			continue
+		}
+
+		if len(args) > 0 {
+			labels[i] = args[0]
+			args = args[1:]
+		}
+	}
+
+	return strings.Join(labels, "."), args
+}
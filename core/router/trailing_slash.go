@@ -0,0 +1,58 @@
+package router
+
+import (
+	"html"
+	"net/http"
+
+	"github.com/kataras/iris/context"
+)
+
+// TrailingSlashPolicy describes how the requests with a trailing slash,
+// i.e "/orders/" for a route registered as "/orders", are handled.
+//
+// It's set per Party, see `Party#TrailingSlash`.
+type TrailingSlashPolicy uint8
+
+const (
+	// TrailingSlashDefault follows the `Configuration#DisablePathCorrection`,
+	// it redirects if path correction is enabled (default), otherwise it's strict.
+	TrailingSlashDefault TrailingSlashPolicy = iota
+	// TrailingSlashRedirect redirects the client to the path without the trailing slash,
+	// with 301 for GET and HEAD and 308 for the rest of the methods
+	// in order to preserve the method and the body of the request.
+	TrailingSlashRedirect
+	// TrailingSlashMatch serves both forms of the path by the same route, without a redirect.
+	TrailingSlashMatch
+	// TrailingSlashStrict serves the path without the trailing slash only,
+	// the path with the trailing slash is not found.
+	TrailingSlashStrict
+)
+
+// redirectPermanently redirects the client to the "path", i.e the request's path without its trailing slash,
+// the query is kept. The 301 status code is used for GET and HEAD methods, the 308 for the rest of them.
+func redirectPermanently(ctx context.Context, path string) {
+	method := ctx.Method()
+
+	r := ctx.Request()
+	r.URL.Path = path
+	url := r.URL.String()
+
+	statusCode := http.StatusMovedPermanently
+	if method != http.MethodGet && method != http.MethodHead {
+		// the 301 makes the clients to change the method to GET and drop the body.
+		statusCode = http.StatusPermanentRedirect
+	}
+
+	ctx.Redirect(url, statusCode)
+
+	// RFC2616 recommends that a short note "SHOULD" be included in the
+	// response because older user agents may not understand 301/307.
+	// Shouldn't send the response for POST or HEAD; that leaves GET.
+	if method == http.MethodGet {
+		note := "<a href=\"" +
+			html.EscapeString(url) +
+			"\">Moved Permanently</a>.\n"
+
+		ctx.ResponseWriter().WriteString(note)
+	}
+}
@@ -0,0 +1,261 @@
+package router
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/iris/context"
+)
+
+// AcceptVersionHeaderKey is the request header which the clients use
+// to request a specific API version or a range of versions, i.e "1.2.0", "^1" or ">= 1.0, < 3".
+// The "version" parameter of the "Accept" header's media types,
+// i.e "application/vnd.api+json; version=2", is used when the header is missing.
+const AcceptVersionHeaderKey = "Accept-Version"
+
+// semver is a parsed "major.minor.patch" version,
+// the pre-release and the metadata of a version are not part of it.
+type semver [3]int
+
+// parseVersion parses a, complete or partial, version, i.e "1.2.3", "v1.2" or "1",
+// the missing parts are zeros.
+func parseVersion(s string) (semver, error) {
+	parts, _, err := versionParts(s)
+	if err == nil && strings.ContainsAny(versionCore(s), "xX*") {
+		err = fmt.Errorf("invalid version: %s", s)
+	}
+	return parts, err
+}
+
+// compare returns -1, 0 or 1 if "v" is less than, equal to or greater than the "other".
+func (v semver) compare(other semver) int {
+	for i := range v {
+		if v[i] < other[i] {
+			return -1
+		}
+		if v[i] > other[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionConstraint compares a version with its "v" by its operator,
+// one of "=", "!=", ">", ">=", "<" and "<=".
+type versionConstraint struct {
+	op string
+	v  semver
+}
+
+func (c versionConstraint) check(v semver) bool {
+	cmp := v.compare(c.v)
+	switch c.op {
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default: // "=".
+		return cmp == 0
+	}
+}
+
+// versionRange is a set of alternative constraints, separated by "||",
+// a version is in the range if it satisfies all the constraints of at least one of them.
+type versionRange [][]versionConstraint
+
+// parseVersionRange parses a semver range, the supported forms are:
+// exact or partial versions ("1.2.3", "1.2", "1", "1.x"),
+// caret ("^1.2.3") and tilde ("~1.2.3") ranges,
+// comparisons (">= 1.0 < 2.0", ">= 1.0, < 2.0", "!= 1.1", "~> 1.2")
+// and alternatives ("^1 || ^3").
+// An empty range or "*" accepts any version.
+func parseVersionRange(s string) (versionRange, error) {
+	var r versionRange
+
+	for _, alt := range strings.Split(s, "||") {
+		// an empty set of constraints accepts any version.
+		constraints := []versionConstraint{}
+
+		tokens := strings.Fields(strings.Replace(alt, ",", " ", -1))
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+			if strings.TrimLeft(token, "<>=!~") == "" && i+1 < len(tokens) {
+				// operator separated by space from its version, i.e ">= 1.0".
+				i++
+				token += tokens[i]
+			}
+
+			c, err := versionConstraints(token)
+			if err != nil {
+				return nil, err
+			}
+			constraints = append(constraints, c...)
+		}
+
+		r = append(r, constraints)
+	}
+
+	return r, nil
+}
+
+// versionConstraints converts a semver range token to its constraints.
+func versionConstraints(token string) ([]versionConstraint, error) {
+	switch {
+	case token == "*" || token == "x" || token == "X":
+		return nil, nil
+	case strings.HasPrefix(token, "^"):
+		parts, n, err := versionParts(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		// the left-most non-zero part can't change.
+		upper := semver{parts[0] + 1, 0, 0}
+		if parts[0] == 0 && n > 1 {
+			upper = semver{0, parts[1] + 1, 0}
+			if parts[1] == 0 && n > 2 {
+				upper = semver{0, 0, parts[2] + 1}
+			}
+		}
+		return betweenVersions(parts, upper), nil
+	case strings.HasPrefix(token, "~>"):
+		// pessimistic, the last given part can be increased.
+		parts, n, err := versionParts(token[2:])
+		if err != nil {
+			return nil, err
+		}
+		upper := semver{parts[0] + 1, 0, 0}
+		if n > 2 {
+			upper = semver{parts[0], parts[1] + 1, 0}
+		}
+		return betweenVersions(parts, upper), nil
+	case strings.HasPrefix(token, "~"):
+		parts, n, err := versionParts(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		upper := semver{parts[0] + 1, 0, 0}
+		if n > 1 {
+			upper = semver{parts[0], parts[1] + 1, 0}
+		}
+		return betweenVersions(parts, upper), nil
+	case strings.TrimLeft(token, "<>=!") != token:
+		// a comparison.
+		op := token[:len(token)-len(strings.TrimLeft(token, "<>=!"))]
+		switch op {
+		case "==":
+			op = "="
+		case "=", "!=", ">", ">=", "<", "<=":
+		default:
+			return nil, fmt.Errorf("invalid version constraint: %s", token)
+		}
+		parts, _, err := versionParts(token[len(op):])
+		if err != nil {
+			return nil, err
+		}
+		return []versionConstraint{{op, parts}}, nil
+	default:
+		parts, n, err := versionParts(token)
+		if err != nil {
+			return nil, err
+		}
+		if n == 3 {
+			return []versionConstraint{{"=", parts}}, nil
+		}
+		// partial, i.e "1" or "1.2.x".
+		upper := semver{parts[0] + 1, 0, 0}
+		if n == 2 {
+			upper = semver{parts[0], parts[1] + 1, 0}
+		}
+		return betweenVersions(parts, upper), nil
+	}
+}
+
+// betweenVersions returns the constraints of the versions
+// which are greater than or equal to the "lower" and less than the "upper".
+func betweenVersions(lower, upper semver) []versionConstraint {
+	return []versionConstraint{{">=", lower}, {"<", upper}}
+}
+
+// versionCore returns the "major.minor.patch" of a version,
+// without its "v" prefix, pre-release and metadata, i.e "v1.2.3-beta" -> "1.2.3".
+func versionCore(s string) string {
+	s = strings.TrimPrefix(s, "v")
+	if idx := strings.IndexAny(s, "-+"); idx != -1 { // pre-release and metadata are not part of the range.
+		s = s[:idx]
+	}
+	return s
+}
+
+// versionParts returns the major, minor and patch of a (partial) version, i.e "1.2.x",
+// and the number of the parts that were given.
+func versionParts(s string) (parts semver, n int, err error) {
+	s = versionCore(s)
+
+	for _, part := range strings.Split(s, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+
+		if n == len(parts) {
+			return parts, n, fmt.Errorf("invalid version: %s", s)
+		}
+
+		parts[n], err = strconv.Atoi(part)
+		if err != nil {
+			return parts, n, fmt.Errorf("invalid version: %s", s)
+		}
+		n++
+	}
+
+	if n == 0 {
+		return parts, n, fmt.Errorf("invalid version: %s", s)
+	}
+
+	return
+}
+
+// check reports whether the "v" satisfies the range.
+func (r versionRange) check(v semver) bool {
+	for _, constraints := range r {
+		ok := true
+		for _, c := range constraints {
+			if !c.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// requestedVersion returns the requested version range of the request,
+// from the "Accept-Version" header or the "version" parameter of the "Accept" header,
+// if missing then it returns an empty string.
+func requestedVersion(ctx context.Context) string {
+	if v := ctx.GetHeader(AcceptVersionHeaderKey); v != "" {
+		return v
+	}
+
+	// i.e application/vnd.api+json; version=2.0
+	for _, mediaRange := range strings.Split(ctx.GetHeader("Accept"), ",") {
+		params := strings.Split(mediaRange, ";")
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "version=") {
+				return strings.Trim(param[len("version="):], `"`)
+			}
+		}
+	}
+
+	return ""
+}
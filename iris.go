@@ -30,6 +30,7 @@ import (
 
 	requestLogger "github.com/kataras/iris/middleware/logger"
 	"github.com/kataras/iris/middleware/recover"
+	"github.com/kataras/iris/middleware/rewrite"
 )
 
 var (
@@ -126,6 +127,13 @@ const (
 // to store the "offline" routes.
 const MethodNone = "NONE"
 
+// RegisterMethod registers custom http methods globally, i.e "PROPFIND", "MKCOL", "LOCK" or "QUERY",
+// they can be served by the `Handle` and the `Any` routes and by controllers' method functions, i.e `Propfind()`.
+// It should be called before the routes registration.
+//
+// A shortcut for the `core/router#RegisterMethod`.
+var RegisterMethod = router.RegisterMethod
+
 // Application is responsible to manage the state of the application.
 // It contains and handles all the necessary parts to create a fast web server.
 type Application struct {
@@ -143,6 +151,8 @@ type Application struct {
 
 	// view engine
 	view view.View
+	// the renderers of the context's Negotiate, see `Renderers`.
+	renderers *context.Renderers
 	// used for build
 	once sync.Once
 
@@ -168,6 +178,7 @@ func New() *Application {
 		logger:     golog.Default,
 		APIBuilder: router.NewAPIBuilder(),
 		Router:     router.NewRouter(),
+		renderers:  context.NewRenderers(),
 	}
 
 	app.ContextPool = context.New(func() context.Context {
@@ -270,6 +281,32 @@ var (
 // A shortcut for the `view#NoLayout`.
 const NoLayout = view.NoLayout
 
+// The trailing slash policies, see `Party#TrailingSlash`.
+// A shortcut for the `core/router#TrailingSlashPolicy` values.
+const (
+	// TrailingSlashRedirect redirects the client to the path without the trailing slash,
+	// with 301 for GET and HEAD and 308 for the rest of the methods.
+	TrailingSlashRedirect = router.TrailingSlashRedirect
+	// TrailingSlashMatch serves both forms of the path by the same route, without a redirect.
+	TrailingSlashMatch = router.TrailingSlashMatch
+	// TrailingSlashStrict serves the path without the trailing slash only.
+	TrailingSlashStrict = router.TrailingSlashStrict
+)
+
+// The path matching options, see `Party#PathMatching`.
+// A shortcut for the `core/router#PathMatching` values.
+const (
+	// PathMatchCaseInsensitive matches the static parts of the path case-insensitively,
+	// the path parameters' values are kept in their original case.
+	PathMatchCaseInsensitive = router.PathMatchCaseInsensitive
+	// PathMatchNormalize normalizes the request path to the Unicode's NFC form before it's matched.
+	PathMatchNormalize = router.PathMatchNormalize
+	// PathMatchRedirect redirects the client to the route's registered form of the path.
+	PathMatchRedirect = router.PathMatchRedirect
+	// PathMatchExact matches the registered path only, it ignores the configuration.
+	PathMatchExact = router.PathMatchExact
+)
+
 // RegisterView should be used to register view engines mapping to a root directory
 // and the template file(s) extension.
 func (app *Application) RegisterView(viewEngine view.Engine) {
@@ -299,6 +336,18 @@ func (app *Application) View(writer io.Writer, filename string, layout string, b
 	return err
 }
 
+// Renderers returns the registry of the renderers by their media types,
+// the `context#Negotiate` selects one of them based on the request's "Accept" header.
+//
+// Register a renderer for a custom format:
+//
+//	app.Renderers().Register("text/csv", context.RendererFunc(func(ctx context.Context, v interface{}) error {
+//		return writeCSV(ctx, v)
+//	}))
+func (app *Application) Renderers() *context.Renderers {
+	return app.renderers
+}
+
 var (
 	// LimitRequestBodySize is a middleware which sets a request body size limit
 	// for all next handlers in the chain.
@@ -630,7 +679,26 @@ func (app *Application) Build() error {
 			// create the request handler, the default routing handler
 			routerHandler := router.NewDefaultHandler()
 
+			if rules := app.config.Rewrites; len(rules) > 0 {
+				// the rewrite rules run before any other router wrapper.
+				engine, err := rewrite.New(app.Macros(), rules...)
+				if err != nil {
+					rp.Describe("rewrite: %v", err)
+				} else {
+					app.WrapRouter(engine.Rewrite)
+				}
+			}
+
 			rp.Describe("router: %v", app.Router.BuildRouter(app.ContextPool, routerHandler, app.APIBuilder))
+
+			// routes that can't be served as they were declared.
+			if err := router.Diagnose(app.APIBuilder.GetRoutes()); err != nil {
+				if app.config.EnableRouteWarningsAsErrors {
+					rp.Describe("routes: %v", err)
+				} else {
+					errors.PrintAndReturnErrors(err, app.logger.Warnf)
+				}
+			}
 			// re-build of the router from outside can be done with;
 			// app.RefreshRouter()
 		}
@@ -0,0 +1,200 @@
+// Package bulkhead provides a middleware which limits the number of the requests
+// that are served at the same time by a route, or by the routes of a Party,
+// the rest of them are waiting at a bounded queue or they're rejected with a 503 Service Unavailable
+// and a "Retry-After" header, it protects the expensive endpoints, i.e a report export,
+// from using all of the server's resources.
+//
+// Usage:
+// exports := bulkhead.New(bulkhead.Config{MaxConcurrent: 2, MaxQueue: 10, QueueTimeout: 5 * time.Second})
+// app.Get("/reports/export", exports.Serve, exportReport)
+//
+// Or limit each one of a Party's routes separately:
+// limits := bulkhead.NewPerRoute(bulkhead.Config{MaxConcurrent: 5})
+// search := app.Party("/search", limits.Serve)
+package bulkhead
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kataras/iris/context"
+)
+
+// Bulkhead limits the requests that are served at the same time by its `Serve` handler.
+type Bulkhead struct {
+	config Config
+	// a slot is taken by each one of the in-flight requests.
+	slots    chan struct{}
+	queued   int64
+	rejected uint64
+	// the "Retry-After" header's value.
+	retryAfter string
+}
+
+// Stats contains the current number of the in-flight and the queued requests
+// and the total number of the rejected requests, useful for monitoring.
+type Stats struct {
+	InFlight int    `json:"inFlight"`
+	Queued   int    `json:"queued"`
+	Rejected uint64 `json:"rejected"`
+}
+
+// New returns a new Bulkhead based on the "c" limits,
+// its `Serve` handler can be registered to a Party or to a route.
+func New(c Config) *Bulkhead {
+	if c.MaxConcurrent <= 0 {
+		c.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if c.MaxQueue < 0 {
+		c.MaxQueue = 0
+	}
+	if c.RetryAfter <= 0 {
+		c.RetryAfter = DefaultRetryAfter
+	}
+
+	// round up to seconds.
+	seconds := int64((c.RetryAfter + time.Second - 1) / time.Second)
+
+	return &Bulkhead{
+		config:     c,
+		slots:      make(chan struct{}, c.MaxConcurrent),
+		retryAfter: strconv.FormatInt(seconds, 10),
+	}
+}
+
+// Serve is the middleware, it serves the request by the next handlers if a slot is free,
+// otherwise it waits at the queue. If the queue is full or the request waited
+// for more than the `Config#QueueTimeout` then it responds with 503 Service Unavailable and a "Retry-After" header.
+func (b *Bulkhead) Serve(ctx context.Context) {
+	if !b.acquire(ctx) {
+		atomic.AddUint64(&b.rejected, 1)
+		ctx.Header("Retry-After", b.retryAfter)
+		ctx.StatusCode(http.StatusServiceUnavailable)
+		ctx.StopExecution()
+		return
+	}
+
+	defer b.release()
+	ctx.Next()
+}
+
+// acquire takes a slot, it reports false if there is no free slot
+// and the request can't wait for one.
+func (b *Bulkhead) acquire(ctx context.Context) bool {
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if atomic.AddInt64(&b.queued, 1) > int64(b.config.MaxQueue) {
+		atomic.AddInt64(&b.queued, -1)
+		return false
+	}
+	defer atomic.AddInt64(&b.queued, -1)
+
+	var timeout <-chan time.Time
+	if b.config.QueueTimeout > 0 {
+		t := time.NewTimer(b.config.QueueTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	case <-timeout:
+		return false
+	case <-ctx.Request().Context().Done():
+		// the client is gone.
+		return false
+	}
+}
+
+func (b *Bulkhead) release() {
+	<-b.slots
+}
+
+// InFlight returns the number of the requests that are being served right now.
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+// Queued returns the number of the requests that are waiting for a free slot right now.
+func (b *Bulkhead) Queued() int {
+	return int(atomic.LoadInt64(&b.queued))
+}
+
+// Rejected returns the total number of the rejected requests.
+func (b *Bulkhead) Rejected() uint64 {
+	return atomic.LoadUint64(&b.rejected)
+}
+
+// Stats returns the current in-flight and queued requests and the total rejected ones.
+func (b *Bulkhead) Stats() Stats {
+	return Stats{
+		InFlight: b.InFlight(),
+		Queued:   b.Queued(),
+		Rejected: b.Rejected(),
+	}
+}
+
+// PerRoute limits each one of the routes that its `Serve` handler is registered to separately,
+// i.e when it's registered to a Party, with the same limits.
+type PerRoute struct {
+	config    Config
+	mu        sync.RWMutex
+	bulkheads map[string]*Bulkhead
+}
+
+// NewPerRoute returns a new PerRoute based on the "c" limits, see `New` too.
+func NewPerRoute(c Config) *PerRoute {
+	return &PerRoute{
+		config:    c,
+		bulkheads: make(map[string]*Bulkhead),
+	}
+}
+
+// Serve is the middleware, it serves the request through the Bulkhead of the current route.
+func (p *PerRoute) Serve(ctx context.Context) {
+	routeName := ""
+	if r := ctx.GetCurrentRoute(); r != nil {
+		routeName = r.Name()
+	}
+
+	p.Get(routeName).Serve(ctx)
+}
+
+// Get returns the Bulkhead of a route based on its name,
+// it's created if it doesn't exist.
+func (p *PerRoute) Get(routeName string) *Bulkhead {
+	p.mu.RLock()
+	b, ok := p.bulkheads[routeName]
+	p.mu.RUnlock()
+	if ok {
+		return b
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if b, ok = p.bulkheads[routeName]; !ok {
+		b = New(p.config)
+		p.bulkheads[routeName] = b
+	}
+	return b
+}
+
+// Stats returns the stats of the routes that are served at least once, by their names.
+func (p *PerRoute) Stats() map[string]Stats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stats := make(map[string]Stats, len(p.bulkheads))
+	for routeName, b := range p.bulkheads {
+		stats[routeName] = b.Stats()
+	}
+	return stats
+}
@@ -0,0 +1,135 @@
+// black-box testing
+package bulkhead_test
+
+import (
+	"net/http"
+	stdhttptest "net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/middleware/bulkhead"
+)
+
+func newApp(t *testing.T, unblock chan struct{}, limits ...context.Handler) *iris.Application {
+	app := iris.New()
+	p := app.Party("/", limits...)
+	blocking := func(ctx context.Context) {
+		<-unblock
+		ctx.WriteString(ctx.Path())
+	}
+	p.Get("/export", blocking)
+	p.Get("/search", blocking)
+
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+// serve serves the request in the background, the response is sent to the returned channel.
+func serve(app *iris.Application, path string) chan *stdhttptest.ResponseRecorder {
+	done := make(chan *stdhttptest.ResponseRecorder, 1)
+	go func() {
+		rec := stdhttptest.NewRecorder()
+		app.ServeHTTP(rec, stdhttptest.NewRequest("GET", path, nil))
+		done <- rec
+	}()
+	return done
+}
+
+// waitFor waits until the "cond" is true.
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	unblock := make(chan struct{})
+	b := bulkhead.New(bulkhead.Config{MaxConcurrent: 1, MaxQueue: 1, RetryAfter: 1500 * time.Millisecond})
+	app := newApp(t, unblock, b.Serve)
+
+	first := serve(app, "/export")
+	waitFor(t, func() bool { return b.InFlight() == 1 })
+	second := serve(app, "/search")
+	waitFor(t, func() bool { return b.Queued() == 1 })
+
+	// no free slot and the queue is full.
+	rec := <-serve(app, "/export")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status code %d but got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("expected Retry-After %q but got %q", "2", got)
+	}
+
+	if expected, got := (bulkhead.Stats{InFlight: 1, Queued: 1, Rejected: 1}), b.Stats(); got != expected {
+		t.Fatalf("expected stats %#v but got %#v", expected, got)
+	}
+
+	close(unblock)
+	for _, done := range []chan *stdhttptest.ResponseRecorder{first, second} {
+		if rec := <-done; rec.Code != http.StatusOK {
+			t.Fatalf("expected status code %d but got %d", http.StatusOK, rec.Code)
+		}
+	}
+
+	if expected, got := (bulkhead.Stats{Rejected: 1}), b.Stats(); got != expected {
+		t.Fatalf("expected stats %#v but got %#v", expected, got)
+	}
+}
+
+func TestBulkheadQueueTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	b := bulkhead.New(bulkhead.Config{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: 20 * time.Millisecond})
+	app := newApp(t, unblock, b.Serve)
+
+	serve(app, "/export")
+	waitFor(t, func() bool { return b.InFlight() == 1 })
+
+	rec := <-serve(app, "/export")
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("expected the queued request to be rejected after the timeout but got %d", rec.Code)
+	}
+
+	if got := b.Queued(); got != 0 {
+		t.Fatalf("expected the queue to be empty but got %d", got)
+	}
+}
+
+func TestBulkheadPerRoute(t *testing.T) {
+	unblock := make(chan struct{})
+	limits := bulkhead.NewPerRoute(bulkhead.Config{MaxConcurrent: 1})
+	app := newApp(t, unblock, limits.Serve)
+
+	export := serve(app, "/export")
+	waitFor(t, func() bool { return limits.Get("GET/export").InFlight() == 1 })
+
+	// the "/search" has its own slot.
+	search := serve(app, "/search")
+	waitFor(t, func() bool { return limits.Get("GET/search").InFlight() == 1 })
+
+	if rec := <-serve(app, "/export"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status code %d but got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	close(unblock)
+	<-export
+	<-search
+
+	stats := limits.Stats()
+	if expected, got := (bulkhead.Stats{Rejected: 1}), stats["GET/export"]; got != expected {
+		t.Fatalf("expected stats %#v but got %#v", expected, got)
+	}
+	if expected, got := (bulkhead.Stats{}), stats["GET/search"]; got != expected || len(stats) != 2 {
+		t.Fatalf("expected stats %#v but got %#v", expected, stats)
+	}
+}
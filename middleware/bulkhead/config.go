@@ -0,0 +1,38 @@
+package bulkhead
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMaxConcurrent is the default number of the requests that are served at the same time.
+	DefaultMaxConcurrent = 10
+	// DefaultRetryAfter is the default value of the "Retry-After" header of the rejected requests.
+	DefaultRetryAfter = 1 * time.Second
+)
+
+// Config contains the limits of the bulkhead middleware.
+type Config struct {
+	// MaxConcurrent is the maximum number of the requests that are served at the same time.
+	// Defaults to 10.
+	MaxConcurrent int
+	// MaxQueue is the maximum number of the requests that are waiting for a free slot
+	// when the MaxConcurrent is reached, the rest of the requests are rejected immediately.
+	// Defaults to 0, no queue.
+	MaxQueue int
+	// QueueTimeout is the maximum duration that a request waits at the queue,
+	// after that it's rejected. Defaults to 0, it waits until a slot is free
+	// or until the client closes the connection.
+	QueueTimeout time.Duration
+	// RetryAfter is the value of the "Retry-After" header, in seconds, of the rejected requests.
+	// Defaults to 1 second.
+	RetryAfter time.Duration
+}
+
+// DefaultConfig returns the default configs for the bulkhead middleware.
+func DefaultConfig() Config {
+	return Config{
+		MaxConcurrent: DefaultMaxConcurrent,
+		RetryAfter:    DefaultRetryAfter,
+	}
+}
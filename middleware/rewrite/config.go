@@ -0,0 +1,47 @@
+package rewrite
+
+// The available modes of a `Rule`.
+const (
+	// ModePermanent redirects the client to the target with a 301 Moved Permanently,
+	// or a 308 Permanent Redirect for methods other than GET and HEAD so the method and body are kept.
+	ModePermanent = "permanent"
+	// ModeTemporary redirects the client to the target with a 302 Found,
+	// or a 307 Temporary Redirect for methods other than GET and HEAD so the method and body are kept.
+	ModeTemporary = "temporary"
+	// ModeRewrite serves the target path internally, the client's URL stays the same.
+	ModeRewrite = "rewrite"
+)
+
+// Rule describes a single rewrite or redirect rule,
+// it can be loaded from the YAML or TOML configuration files, see `iris.Configuration#Rewrites`.
+//
+// Example (YAML):
+//
+// Rewrites:
+//   - Source: "/docs/{version:int}/{page:path}"
+//     Target: "/documentation/v{version}/{page}"
+//   - Source: "^/blog/([0-9]{4})/(.+)\\.html$"
+//     Target: "/posts/$1/$2"
+//     Mode: "rewrite"
+//   - Source: "/shop"
+//     Target: "https://shop.mydomain.com"
+//     Mode: "temporary"
+//     Hosts: ["mydomain.com", "*.mydomain.com"]
+type Rule struct {
+	// Source is the pattern that the request's path should match.
+	// If it starts with "^" then it's a regular expression,
+	// its groups can be used at the target as "$1" or "${name}".
+	// Otherwise it's a route path, i.e "/users/{id:int min(1)}/{rest:path}",
+	// its parameters can be used at the target as "{id}" or "${id}".
+	Source string `json:"source" yaml:"Source" toml:"Source"`
+	// Target is the path or the full URL that the request is redirected or rewritten to.
+	// The request's query is kept if the target has not a query of its own.
+	Target string `json:"target" yaml:"Target" toml:"Target"`
+	// Mode is one of the "permanent", "temporary" or "rewrite".
+	//
+	// Defaults to "permanent".
+	Mode string `json:"mode,omitempty" yaml:"Mode" toml:"Mode"`
+	// Hosts, if not empty, limits the rule to requests of those hosts,
+	// the port is ignored and each one can be a pattern, i.e "*.mydomain.com".
+	Hosts []string `json:"hosts,omitempty" yaml:"Hosts" toml:"Hosts"`
+}
@@ -0,0 +1,252 @@
+// Package rewrite provides a rules engine which redirects or rewrites
+// the requests' URLs before they reach the router, it's useful to migrate legacy URLs.
+//
+// Usage:
+// engine, err := rewrite.New(app.Macros(), rules...)
+// app.WrapRouter(engine.Rewrite)
+//
+// Or set the `iris.Configuration#Rewrites` field, i.e through the YAML or TOML configuration files,
+// and the application will do that on `Build`.
+package rewrite
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kataras/iris/core/router/macro"
+	"github.com/kataras/iris/core/router/macro/interpreter/ast"
+)
+
+// Engine is the compiled list of the rewrite rules,
+// the first rule that matches a request wins.
+type Engine struct {
+	rules []*rule
+}
+
+type rule struct {
+	Rule
+	expr *regexp.Regexp
+	// the macro parameters of a route path source, if any,
+	// with the index of their group.
+	params []ruleParam
+	// target with the "{name}" converted to the regexp's "${name}".
+	target string
+}
+
+type ruleParam struct {
+	macro.TemplateParam
+	group int
+}
+
+// New compiles the "rules" and returns a new rewrite Engine.
+// The "macros" are used to parse the route path sources,
+// pass the `app.Macros()` to use the application's parameter types and functions.
+//
+// It returns an error if a rule is invalid.
+func New(macros *macro.Map, rules ...Rule) (*Engine, error) {
+	if macros == nil {
+		macros = macro.NewMap()
+	}
+
+	e := new(Engine)
+	for _, r := range rules {
+		compiled, err := compile(r, macros)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %v", r.Source, err)
+		}
+		e.rules = append(e.rules, compiled)
+	}
+
+	return e, nil
+}
+
+// Must is like `New` but it panics on error.
+func Must(macros *macro.Map, rules ...Rule) *Engine {
+	e, err := New(macros, rules...)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func compile(r Rule, macros *macro.Map) (*rule, error) {
+	if r.Mode == "" {
+		r.Mode = ModePermanent
+	}
+
+	switch r.Mode {
+	case ModePermanent, ModeTemporary:
+	case ModeRewrite:
+		if u, err := url.Parse(r.Target); err != nil || u.IsAbs() || u.Host != "" {
+			return nil, fmt.Errorf("target of a rewrite should be a path: %s", r.Target)
+		}
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", r.Mode)
+	}
+
+	if r.Source == "" || r.Target == "" {
+		return nil, fmt.Errorf("source and target are required")
+	}
+
+	for _, host := range r.Hosts {
+		if _, err := path.Match(host, ""); err != nil {
+			return nil, fmt.Errorf("invalid host pattern: %s", host)
+		}
+	}
+
+	compiled := &rule{Rule: r, target: convertTarget(r.Target)}
+
+	if strings.HasPrefix(r.Source, "^") {
+		expr, err := regexp.Compile(r.Source)
+		if err != nil {
+			return nil, err
+		}
+		compiled.expr = expr
+		return compiled, nil
+	}
+
+	tmpl, err := macro.Parse(r.Source, macros)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert the route path to a regexp, each parameter is a named group.
+	expr, rest := "^", r.Source
+	for _, p := range tmpl.Params {
+		idx := strings.Index(rest, p.Src)
+		expr += regexp.QuoteMeta(rest[:idx])
+		if p.Type == ast.ParamTypePath {
+			expr += "(?P<" + p.Name + ">.*)"
+		} else {
+			expr += "(?P<" + p.Name + ">[^/]+)"
+		}
+		rest = rest[idx+len(p.Src):]
+	}
+	expr += regexp.QuoteMeta(rest) + "$"
+
+	if strings.IndexByte(expr, '{') != -1 {
+		// not parsed as a parameter, i.e "/v{version}".
+		return nil, fmt.Errorf("a parameter should be a whole path segment")
+	}
+
+	if compiled.expr, err = regexp.Compile(expr); err != nil {
+		return nil, err
+	}
+
+	for i, p := range tmpl.Params {
+		compiled.params = append(compiled.params, ruleParam{TemplateParam: p, group: i + 1})
+	}
+
+	return compiled, nil
+}
+
+// convertTarget converts the "{name}" of the target to the "${name}".
+func convertTarget(target string) string {
+	var b bytes.Buffer
+	for i := 0; i < len(target); i++ {
+		if target[i] == '{' && (i == 0 || target[i-1] != '$') {
+			if end := strings.IndexByte(target[i:], '}'); end != -1 {
+				b.WriteString("${" + target[i+1:i+end] + "}")
+				i += end
+				continue
+			}
+		}
+		b.WriteByte(target[i])
+	}
+	return b.String()
+}
+
+// matchHost reports whether the request's host matches one of the rule's hosts.
+func (r *rule) matchHost(req *http.Request) bool {
+	if len(r.Hosts) == 0 {
+		return true
+	}
+
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	for _, pattern := range r.Hosts {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expand returns the rule's target for the request path, if it matches.
+func (r *rule) expand(requestPath string) (string, bool) {
+	m := r.expr.FindStringSubmatchIndex(requestPath)
+	if m == nil {
+		return "", false
+	}
+
+	for _, p := range r.params {
+		if !p.Eval(requestPath[m[2*p.group]:m[2*p.group+1]]) {
+			return "", false
+		}
+	}
+
+	return string(r.expr.ExpandString(nil, r.target, requestPath, m)), true
+}
+
+// Rewrite is the `router.WrapperFunc` of the engine, register it with `app.WrapRouter(engine.Rewrite)`.
+// It redirects or rewrites the request based on the first matching rule,
+// if none matches then the router serves the request as it is.
+func (e *Engine) Rewrite(w http.ResponseWriter, r *http.Request, router http.HandlerFunc) {
+	for _, rule := range e.rules {
+		if !rule.matchHost(r) {
+			continue
+		}
+
+		target, ok := rule.expand(r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		if r.URL.RawQuery != "" && strings.IndexByte(target, '?') == -1 {
+			target += "?" + r.URL.RawQuery
+		}
+
+		if rule.Mode == ModeRewrite {
+			u, err := url.Parse(target)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			r.URL.Path = u.Path
+			r.URL.RawPath = ""
+			r.URL.RawQuery = u.RawQuery
+			r.RequestURI = r.URL.RequestURI()
+			break
+		}
+
+		code := http.StatusMovedPermanently
+		if rule.Mode == ModeTemporary {
+			code = http.StatusFound
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			// keep the method and the body.
+			if code == http.StatusFound {
+				code = http.StatusTemporaryRedirect
+			} else {
+				code = http.StatusPermanentRedirect
+			}
+		}
+
+		http.Redirect(w, r, target, code)
+		return
+	}
+
+	router(w, r)
+}
@@ -0,0 +1,93 @@
+// black-box testing
+package rewrite_test
+
+import (
+	"net/http"
+	stdhttptest "net/http/httptest"
+	"testing"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/httptest"
+	"github.com/kataras/iris/middleware/rewrite"
+)
+
+func newApp(rules ...rewrite.Rule) *iris.Application {
+	app := iris.New()
+	app.Configure(iris.WithRewrites(rules...))
+
+	app.Get("/documentation/{version}/{page:path}", func(ctx context.Context) {
+		ctx.Writef("docs %s %s", ctx.Params().Get("version"), ctx.Params().Get("page"))
+	})
+	app.Get("/posts/{year}/{slug}", func(ctx context.Context) {
+		ctx.Writef("post %s %s %s", ctx.Params().Get("year"), ctx.Params().Get("slug"), ctx.URLParam("ref"))
+	})
+	app.Post("/api/v2/users", func(ctx context.Context) {
+		ctx.Writef("users")
+	})
+	return app
+}
+
+func TestRewrite(t *testing.T) {
+	app := newApp(
+		rewrite.Rule{Source: "/docs/{version:int min(2)}/{page:path}", Target: "/documentation/v{version}/{page}"},
+		rewrite.Rule{Source: `^/blog/([0-9]{4})/(.+)\.html$`, Target: "/posts/$1/$2", Mode: rewrite.ModeRewrite},
+		rewrite.Rule{Source: "/api/v1/{rest:path}", Target: "/api/v2/${rest}", Mode: rewrite.ModeTemporary},
+		rewrite.Rule{Source: "/shop", Target: "https://shop.mydomain.com/", Hosts: []string{"*.mydomain.com"}},
+	)
+
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, host, path string
+		status             int
+		location           string
+	}{
+		{"GET", "mydomain.com", "/docs/2/intro/install", http.StatusMovedPermanently, "/documentation/v2/intro/install"},
+		{"GET", "mydomain.com", "/docs/2/intro?lang=en", http.StatusMovedPermanently, "/documentation/v2/intro?lang=en"},
+		{"GET", "mydomain.com", "/docs/1/intro", http.StatusNotFound, ""}, // min(2).
+		{"POST", "mydomain.com", "/api/v1/users", http.StatusTemporaryRedirect, "/api/v2/users"},
+		{"GET", "api.mydomain.com:8080", "/shop", http.StatusMovedPermanently, "https://shop.mydomain.com/"},
+		{"GET", "mydomain.com", "/shop", http.StatusNotFound, ""},
+	}
+
+	for i, tt := range tests {
+		req := stdhttptest.NewRequest(tt.method, tt.path, nil)
+		req.Host = tt.host
+		rec := stdhttptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Fatalf("[%d] %s %s: expected status code %d but got %d", i, tt.method, tt.path, tt.status, rec.Code)
+		}
+
+		if got := rec.Header().Get("Location"); got != tt.location {
+			t.Fatalf("[%d] %s %s: expected location %q but got %q", i, tt.method, tt.path, tt.location, got)
+		}
+	}
+
+	e := httptest.New(t, app)
+	e.GET("/blog/2017/hello.html").WithQuery("ref", "feed").Expect().
+		Status(httptest.StatusOK).Body().Equal("post 2017 hello feed")
+}
+
+func TestRewriteInvalidRule(t *testing.T) {
+	if _, err := rewrite.New(nil, rewrite.Rule{Source: "/old", Target: "https://mydomain.com", Mode: rewrite.ModeRewrite}); err == nil {
+		t.Fatalf("expected an error for an absolute target of a rewrite")
+	}
+
+	if _, err := rewrite.New(nil, rewrite.Rule{Source: "/old", Target: "/new", Mode: "forever"}); err == nil {
+		t.Fatalf("expected an error for an invalid mode")
+	}
+
+	if _, err := rewrite.New(nil, rewrite.Rule{Source: "/docs/v{version}", Target: "/new"}); err == nil {
+		t.Fatalf("expected an error for a parameter which is not a whole path segment")
+	}
+
+	app := newApp(rewrite.Rule{Source: "^/old(", Target: "/new"})
+	if err := app.Build(); err == nil {
+		t.Fatalf("expected the invalid rule to be reported on build")
+	}
+}
@@ -173,6 +173,14 @@ type RegisterFunc func(relPath string, httpMethod string, handler ...context.Han
 // Not useful for the end-developer, but may needed for debugging
 // at the future.
 func RegisterMethodHandlers(t TController, registerFunc RegisterFunc) {
+	RegisterMethodHandlersWithOptions(t, methodfunc.Options{}, registerFunc)
+}
+
+// RegisterMethodHandlersWithOptions same as `RegisterMethodHandlers`
+// but the method handlers are resolved based on the "opts", i.e the application's macros,
+// see `methodfunc.Options`.
+// It returns the resolved method funcs.
+func RegisterMethodHandlersWithOptions(t TController, opts methodfunc.Options, registerFunc RegisterFunc) []methodfunc.MethodFunc {
 	var middleware context.Handlers
 
 	if t.binder != nil {
@@ -182,7 +190,7 @@ func RegisterMethodHandlers(t TController, registerFunc RegisterFunc) {
 	}
 	// the actual method functions
 	// i.e for "GET" it's the `Get()`.
-	methods, err := methodfunc.Resolve(t.Type)
+	methods, err := methodfunc.ResolveWithOptions(t.Type, opts)
 	if err != nil {
 		golog.Errorf("MVC %s: %s", t.FullName, err.Error())
 		// don't stop here.
@@ -208,6 +216,8 @@ func RegisterMethodHandlers(t TController, registerFunc RegisterFunc) {
 			m.Index,
 			m.Name)
 	}
+
+	return methods
 }
 
 // Register receives a "controller",
@@ -216,13 +226,22 @@ func RegisterMethodHandlers(t TController, registerFunc RegisterFunc) {
 func Register(controller BaseController, bindValues []interface{},
 	registerFunc RegisterFunc) error {
 
+	_, err := RegisterWithOptions(controller, bindValues, methodfunc.Options{}, registerFunc)
+	return err
+}
+
+// RegisterWithOptions same as `Register` but the controller's method handlers
+// are resolved based on the "opts", see `RegisterMethodHandlersWithOptions`.
+// It returns the resolved method funcs.
+func RegisterWithOptions(controller BaseController, bindValues []interface{}, opts methodfunc.Options,
+	registerFunc RegisterFunc) ([]methodfunc.MethodFunc, error) {
+
 	CallOnActivate(controller, &bindValues, registerFunc)
 
 	t, err := ActivateController(controller, bindValues)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	RegisterMethodHandlers(t, registerFunc)
-	return nil
+	return RegisterMethodHandlersWithOptions(t, opts, registerFunc), nil
 }
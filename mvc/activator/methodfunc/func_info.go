@@ -22,6 +22,18 @@ var availableMethods = [...]string{
 	"TRACE",
 }
 
+// the "ANY", "ALL" and "NONE" at the start of the availableMethods.
+const virtualMethods = 3
+
+// httpMethods returns the methods that the method functions can be mapped to,
+// the "ANY", "ALL", "NONE" and the "methods", or the standard http methods if empty.
+func httpMethods(methods []string) []string {
+	if len(methods) == 0 {
+		return availableMethods[:]
+	}
+	return append(availableMethods[:virtualMethods:virtualMethods], methods...)
+}
+
 // FuncInfo is part of the `TController`,
 // it contains the index for a specific http method,
 // taken from user's controller struct.
@@ -46,45 +58,50 @@ type FuncInfo struct {
 	HTTPMethod string
 }
 
-// or resolve methods
-func fetchInfos(typ reflect.Type) (methods []FuncInfo) {
+// or resolve methods,
+// the "httpMethods" are the valid http methods, see `Options#Methods`.
+func fetchInfos(typ reflect.Type, httpMethods []string) (methods []FuncInfo) {
 	// search the entire controller
 	// for any compatible method function
 	// and add that.
 	for i, n := 0, typ.NumMethod(); i < n; i++ {
 		m := typ.Method(i)
 		name := m.Name
+		// the whole first word is the method, i.e "Get" for the "GetPurge",
+		// so it can't be mapped to an other method which starts with the same letters.
+		word := methodWord(name)
 
-		for _, method := range availableMethods {
-			possibleMethodFuncName := methodTitle(method)
-
-			if strings.Index(name, possibleMethodFuncName) == 0 {
-				trailing := ""
-				// if has chars after the method itself
-				if lname, lmethod := len(name), len(possibleMethodFuncName); lname > lmethod {
-					ch := rune(name[lmethod])
-					// if the next char is upper, otherise just skip the whole func info.
-					if unicode.IsUpper(ch) {
-						trailing = name[lmethod:]
-					} else {
-						continue
-					}
-				}
+		for _, method := range httpMethods {
+			if methodTitle(method) != word {
+				continue
+			}
 
-				methodInfo := FuncInfo{
-					Name:       name,
-					Trailing:   trailing,
-					Type:       m.Type,
-					HTTPMethod: method,
-					Index:      m.Index,
-				}
-				methods = append(methods, methodInfo)
+			methodInfo := FuncInfo{
+				Name: name,
+				// the chars after the method itself, if any.
+				Trailing:   name[len(word):],
+				Type:       m.Type,
+				HTTPMethod: method,
+				Index:      m.Index,
 			}
+			methods = append(methods, methodInfo)
+			break
 		}
 	}
 	return
 }
 
+// methodWord returns the first word of the method function's name,
+// i.e "Get" for the "GetBy" and "Propfind" for the "Propfind".
+func methodWord(name string) string {
+	for i, ch := range name {
+		if i > 0 && unicode.IsUpper(ch) {
+			return name[:i]
+		}
+	}
+	return name
+}
+
 func methodTitle(httpMethod string) string {
 	httpMethodFuncName := strings.Title(strings.ToLower(httpMethod))
 	return httpMethodFuncName
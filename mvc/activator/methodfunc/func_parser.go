@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/router/macro"
+	macroast "github.com/kataras/iris/core/router/macro/interpreter/ast"
 )
 
 var posWords = map[int]string{
@@ -47,12 +49,15 @@ var macroTypes = map[string]string{
 type funcParser struct {
 	info  FuncInfo
 	lexer *lexer
+	// macros resolves the custom param types, it can be nil.
+	macros *macro.Map
 }
 
-func newFuncParser(info FuncInfo) *funcParser {
+func newFuncParser(info FuncInfo, macros *macro.Map) *funcParser {
 	return &funcParser{
-		info:  info,
-		lexer: newLexer(info.Trailing),
+		info:   info,
+		lexer:  newLexer(info.Trailing),
+		macros: macros,
 	}
 }
 
@@ -106,6 +111,15 @@ func (p *funcParser) parse() (*ast, error) {
 	return a, nil
 }
 
+// lookupConvertedType returns the custom param type of the application's macros
+// which its values are converted to the "typ".
+func (p *funcParser) lookupConvertedType(typ reflect.Type) macroast.ParamType {
+	if p.macros == nil {
+		return macroast.ParamTypeUnExpected
+	}
+	return p.macros.LookupConvertedType(typ)
+}
+
 func (p *funcParser) parsePathParam(a *ast, w string, funcArgPos int) error {
 	typ := p.info.Type
 
@@ -134,6 +148,10 @@ func (p *funcParser) parsePathParam(a *ast, w string, funcArgPos int) error {
 	} else if pType, ok := macroTypes[goType]; ok {
 		// it's not wildcard, so check base on our available macro types.
 		paramType = pType
+	} else if pType := p.lookupConvertedType(typ.In(funcArgPos)); pType != macroast.ParamTypeUnExpected {
+		// custom param types that their values are converted to this type,
+		// i.e "uuid" for uuid.UUID, see `app.Macros().Register`.
+		paramType = pType.String()
 	} else {
 		return errors.New("invalid syntax for " + p.info.Name)
 	}
@@ -207,6 +225,10 @@ func getParamValueFromType(ctx context.Context, paramType string, paramKey strin
 		return reflect.ValueOf(v)
 	}
 
-	// string, path...
-	return reflect.ValueOf(ctx.Params().Get(paramKey))
+	if paramType == paramTypeString || paramType == paramTypePath {
+		return reflect.ValueOf(ctx.Params().Get(paramKey))
+	}
+
+	// custom param types, their values are already converted by the router.
+	return reflect.ValueOf(ctx.Params().GetValue(paramKey))
 }
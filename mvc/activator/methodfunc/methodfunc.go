@@ -5,6 +5,7 @@ import (
 
 	"github.com/kataras/iris/context"
 	"github.com/kataras/iris/core/errors"
+	"github.com/kataras/iris/core/router/macro"
 )
 
 // MethodFunc the handler function.
@@ -23,15 +24,33 @@ type MethodFunc struct {
 	RelPath    string
 }
 
+// Options are the optional settings of the `ResolveWithOptions`.
+type Options struct {
+	// Macros are the application's macros, they resolve the input arguments
+	// of the custom param types, i.e GetBy(id uuid.UUID), it can be nil.
+	Macros *macro.Map
+	// Methods are the http methods that the method funcs can be mapped to,
+	// including the custom ones, i.e "PROPFIND" for the `Propfind()` or `PropfindBy(id int)`,
+	// the "ANY", "ALL" and "NONE" are always valid.
+	// Defaults to the standard http methods.
+	Methods []string
+}
+
 // Resolve returns all the method funcs
 // necessary information and actions to
 // perform the request.
 func Resolve(typ reflect.Type) ([]MethodFunc, error) {
+	return ResolveWithOptions(typ, Options{})
+}
+
+// ResolveWithOptions same as `Resolve` but the method funcs
+// are resolved based on the "opts", see `Options`.
+func ResolveWithOptions(typ reflect.Type, opts Options) ([]MethodFunc, error) {
 	r := errors.NewReporter()
 	var methodFuncs []MethodFunc
-	infos := fetchInfos(typ)
+	infos := fetchInfos(typ, httpMethods(opts.Methods))
 	for _, info := range infos {
-		parser := newFuncParser(info)
+		parser := newFuncParser(info, opts.Macros)
 		a, err := parser.parse()
 		if r.AddErr(err) {
 			continue
@@ -11,6 +11,8 @@ import (
 
 	"github.com/kataras/iris/core/router"
 	"github.com/kataras/iris/httptest"
+
+	"github.com/satori/go.uuid"
 )
 
 type testController struct {
@@ -499,6 +501,26 @@ func TestControllerRelPathFromFunc(t *testing.T) {
 		Body().Equal("GET:/anything/here")
 }
 
+type testControllerCustomParamType struct{ mvc.Controller }
+
+func (c *testControllerCustomParamType) GetBy(id uuid.UUID) {
+	c.Ctx.Writef("id: %s, version: %d", id, id.Version())
+}
+
+func TestControllerCustomParamType(t *testing.T) {
+	app := iris.New()
+	app.Macros().Register("uuid", func(paramValue string) bool {
+		_, err := uuid.FromString(paramValue)
+		return err == nil
+	}, uuid.FromString)
+	app.Controller("/users", new(testControllerCustomParamType))
+
+	e := httptest.New(t, app)
+	e.GET("/users/6ba7b810-9dad-41d1-80b4-00c04fd430c8").Expect().Status(iris.StatusOK).
+		Body().Equal("id: 6ba7b810-9dad-41d1-80b4-00c04fd430c8, version: 4")
+	e.GET("/users/kataras").Expect().Status(iris.StatusNotFound)
+}
+
 type testControllerActivateListener struct {
 	mvc.Controller
 
@@ -528,3 +550,46 @@ func TestControllerActivateListener(t *testing.T) {
 	e.GET("/manual").Expect().Status(iris.StatusOK).
 		Body().Equal("my title")
 }
+
+type testControllerCustomMethods struct {
+	mvc.Controller
+}
+
+func (c *testControllerCustomMethods) Propfind() {
+	writeMethod(c.Controller)
+}
+
+func (c *testControllerCustomMethods) MkcolBy(name string) {
+	c.Ctx.Writef("%s %s", c.Ctx.Method(), name)
+}
+
+func (c *testControllerCustomMethods) Purge() {
+	writeMethod(c.Controller)
+}
+
+func (c *testControllerCustomMethods) GetPurge() {
+	c.Ctx.Writef("%s purge", c.Ctx.Method())
+}
+
+func TestControllerCustomMethods(t *testing.T) {
+	// the custom methods are registered globally, the rest of the tests
+	// are ranging over the `AllMethods` which doesn't contain them.
+	iris.RegisterMethod("PROPFIND", "MKCOL", "PURGE", "PROP")
+
+	app := iris.New()
+	app.Controller("/files", new(testControllerCustomMethods))
+
+	e := httptest.New(t, app)
+	e.Request("PROPFIND", "/files").Expect().Status(iris.StatusOK).
+		Body().Equal("PROPFIND")
+	e.Request("MKCOL", "/files/docs").Expect().Status(iris.StatusOK).
+		Body().Equal("MKCOL docs")
+	e.GET("/files").Expect().Status(iris.StatusNotFound)
+	// the method is the whole first word of the function's name.
+	e.Request("PURGE", "/files").Expect().Status(iris.StatusOK).
+		Body().Equal("PURGE")
+	e.GET("/files/purge").Expect().Status(iris.StatusOK).
+		Body().Equal("GET purge")
+	e.Request("PURGE", "/files/purge").Expect().Status(iris.StatusNotFound)
+	e.Request("PROP", "/files").Expect().Status(iris.StatusNotFound)
+}
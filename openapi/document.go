@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Version is the OpenAPI Specification version of the generated documents.
+const Version = "3.0.0"
+
+type (
+	// Document is the root object of an OpenAPI 3 document.
+	Document struct {
+		OpenAPI    string               `json:"openapi" yaml:"openapi"`
+		Info       Info                 `json:"info" yaml:"info"`
+		Servers    []Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Paths      map[string]*PathItem `json:"paths" yaml:"paths"`
+		Components *Components          `json:"components,omitempty" yaml:"components,omitempty"`
+	}
+
+	// Info provides metadata about the API.
+	Info struct {
+		Title       string `json:"title" yaml:"title"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		Version     string `json:"version" yaml:"version"`
+	}
+
+	// Server is an object representing a server, i.e "https://api.mydomain.com/v1".
+	Server struct {
+		URL         string `json:"url" yaml:"url"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// PathItem describes the operations available on a single path,
+	// the keys are the lowercase http methods, i.e "get".
+	PathItem map[string]*Operation
+
+	// Operation describes a single API operation on a path.
+	Operation struct {
+		OperationID string               `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+		Summary     string               `json:"summary,omitempty" yaml:"summary,omitempty"`
+		Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+		Tags        []string             `json:"tags,omitempty" yaml:"tags,omitempty"`
+		Parameters  []*Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		RequestBody *RequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+		Responses   map[string]*Response `json:"responses" yaml:"responses"`
+		Deprecated  bool                 `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	}
+
+	// Parameter describes a single operation parameter, i.e a path parameter.
+	Parameter struct {
+		Name        string  `json:"name" yaml:"name"`
+		In          string  `json:"in" yaml:"in"`
+		Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+		Required    bool    `json:"required" yaml:"required"`
+		Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	}
+
+	// RequestBody describes a single request body.
+	RequestBody struct {
+		Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+		Content     map[string]*MediaType `json:"content" yaml:"content"`
+		Required    bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+	}
+
+	// Response describes a single response from an API Operation.
+	Response struct {
+		Description string                `json:"description" yaml:"description"`
+		Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	}
+
+	// MediaType provides the schema for a content type, i.e "application/json".
+	MediaType struct {
+		Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	}
+
+	// Components holds the reusable schemas, they are referenced by the "#/components/schemas/{Name}".
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	}
+
+	// Schema is a subset of the JSON Schema which is used by the OpenAPI 3 to describe data types.
+	Schema struct {
+		Ref                  string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+		Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+		Description          string             `json:"description,omitempty" yaml:"description,omitempty"`
+		Pattern              string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+		Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+		Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+		MinLength            *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+		MaxLength            *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+		Enum                 []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Nullable             bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+		Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+		Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+		OneOf                []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	}
+)
+
+// JSON returns the document encoded as JSON.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the document encoded as YAML.
+func (d *Document) YAML() ([]byte, error) {
+	return yaml.Marshal(d)
+}
@@ -0,0 +1,562 @@
+// Package openapi generates OpenAPI 3 documents based on the registered routes,
+// their path parameters' macro types and functions, i.e {id:int min(1)},
+// and the controllers' method functions input arguments and results.
+//
+// Example code:
+//
+//	app := iris.New()
+//	app.Get("/users/{id:int min(1)}", getUser)
+//	app.Controller("/books", new(BooksController))
+//
+//	docs := openapi.New(openapi.Config{Title: "My API", Version: "1.0.0"})
+//	docs.Response("GET/users/{id:int min(1)}", iris.StatusOK, User{})
+//	app.Get("/openapi.json", docs.Handler(app.APIBuilder))
+//	app.Get("/openapi.yaml", docs.Handler(app.APIBuilder))
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/core/router"
+	"github.com/kataras/iris/core/router/macro"
+	"github.com/kataras/iris/core/router/macro/interpreter/ast"
+	"github.com/kataras/iris/core/router/macro/interpreter/parser"
+	"github.com/kataras/iris/mvc/activator/methodfunc"
+)
+
+// Config contains the information of the generated documents.
+type Config struct {
+	// Title is the title of the API.
+	Title string
+	// Description is the, optional, description of the API.
+	Description string
+	// Version is the version of the API, not the OpenAPI's one.
+	Version string
+	// Servers are the, optional, base urls of the API, i.e "https://api.mydomain.com".
+	Servers []string
+	// Subdomain describes the routes of a specific subdomain, i.e "admin.",
+	// defaults to the routes of the root domain.
+	Subdomain string
+	// Host describes the routes of a specific host, i.e "api.mydomain.com", see `Party#Host`,
+	// defaults to the routes that are not bound to a host.
+	Host string
+}
+
+// Generator generates OpenAPI 3 documents based on the registered routes.
+//
+// The request bodies and the responses that can't be resolved
+// by the routes can be described by the `RequestBody` and `Response`.
+type Generator struct {
+	config        Config
+	requestBodies map[string]reflect.Type
+	responses     map[string]map[int]reflect.Type
+}
+
+// New returns a new OpenAPI document generator.
+func New(c Config) *Generator {
+	return &Generator{
+		config:        c,
+		requestBodies: make(map[string]reflect.Type),
+		responses:     make(map[string]map[int]reflect.Type),
+	}
+}
+
+// RequestBody describes the JSON request body of a route, based on its name,
+// the schema is generated from the type of the "v", i.e User{}.
+//
+// Returns itself.
+func (g *Generator) RequestBody(routeName string, v interface{}) *Generator {
+	g.requestBodies[routeName] = reflect.TypeOf(v)
+	return g
+}
+
+// Response describes a JSON response of a route, based on its name and the response's status code,
+// the schema is generated from the type of the "v", i.e User{}, "v" can be nil for an empty response.
+// It overrides the responses that are resolved by the route's controller method function, if any.
+//
+// Returns itself.
+func (g *Generator) Response(routeName string, statusCode int, v interface{}) *Generator {
+	if g.responses[routeName] == nil {
+		g.responses[routeName] = make(map[int]reflect.Type)
+	}
+	g.responses[routeName][statusCode] = reflect.TypeOf(v)
+	return g
+}
+
+// the http methods that the OpenAPI 3 supports, the rest routes are not described.
+var operationMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodPut:     true,
+	http.MethodPost:    true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+	http.MethodHead:    true,
+	http.MethodPatch:   true,
+	http.MethodTrace:   true,
+}
+
+// Generate returns a new OpenAPI 3 document which describes the "routes".
+//
+// The routes with equivalent paths, i.e /users/{id:int} and /users/{username:string},
+// are described by a single path, i.e /users/{id}, which takes the parameters' names of the first route,
+// the parameters with different types are described by a "oneOf" schema.
+func (g *Generator) Generate(routes []*router.Route) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       g.config.Title,
+			Description: g.config.Description,
+			Version:     g.config.Version,
+		},
+		Paths: make(map[string]*PathItem),
+	}
+
+	for _, url := range g.config.Servers {
+		doc.Servers = append(doc.Servers, Server{URL: url})
+	}
+
+	var (
+		s = newSchemas()
+		// the first path of the equivalent templated paths, i.e /users/{id}
+		// for the /users/{id:int} and the /users/{username:string}.
+		paths = make(map[string]string)
+	)
+
+	for _, r := range routes {
+		if !operationMethods[r.Method] || r.Host != g.config.Host || r.Subdomain != g.config.Subdomain {
+			continue
+		}
+
+		path, op := g.operation(r, s)
+		key := templatedPathKey(path)
+		if existing, ok := paths[key]; ok {
+			renameParameters(op, path, existing)
+			path = existing
+		} else {
+			paths[key] = path
+		}
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		method := strings.ToLower(r.Method)
+		if existing, ok := (*item)[method]; ok {
+			// routes that are sharing the same method and path but different param types.
+			mergeOperation(existing, op)
+			continue
+		}
+		(*item)[method] = op
+	}
+
+	if len(s.components) > 0 {
+		doc.Components = &Components{Schemas: s.components}
+	}
+
+	return doc
+}
+
+// Handler returns a handler which serves the OpenAPI 3 document of the "provider"'s routes,
+// i.e app.APIBuilder. The document is served as YAML if the request path ends with ".yaml" or ".yml",
+// otherwise as JSON.
+//
+// The document is generated on each request, so the routes
+// that are added or removed at serve-time are described as well.
+func (g *Generator) Handler(provider router.RoutesProvider) context.Handler {
+	return func(ctx context.Context) {
+		doc := g.Generate(provider.GetRoutes())
+
+		if path := ctx.Path(); strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+			b, err := doc.YAML()
+			if err != nil {
+				ctx.StatusCode(http.StatusInternalServerError)
+				return
+			}
+			ctx.ContentType(context.ContentYAMLHeaderValue)
+			ctx.Write(b)
+			return
+		}
+
+		ctx.JSON(doc)
+	}
+}
+
+func (g *Generator) operation(r *router.Route, s *schemas) (string, *Operation) {
+	op := &Operation{Responses: make(map[string]*Response)}
+
+	if !r.HasDefaultName() {
+		op.OperationID = r.Name
+	}
+
+	op.Summary = r.Summary
+	op.Description = r.Description
+	op.Deprecated = r.Deprecated
+
+	path, params := pathParameters(r, s)
+	op.Parameters = params
+
+	// the params with a custom error code, i.e {id:int else 400}.
+	for _, p := range r.Tmpl().Params {
+		if p.ErrCode != http.StatusNotFound {
+			op.Responses[strconv.Itoa(p.ErrCode)] = &Response{Description: "Invalid " + p.Name}
+		}
+	}
+
+	if info := r.ControllerMethod; info != nil {
+		controllerName := info.Type.In(0).Elem().Name()
+		op.Tags = []string{controllerName}
+		if op.OperationID == "" {
+			op.OperationID = controllerName + "." + info.Name
+			if info.HTTPMethod != r.Method { // registered to all methods.
+				op.OperationID += "." + strings.ToLower(r.Method)
+			}
+		}
+
+		for code, res := range controllerResponses(info, s) {
+			op.Responses[code] = res
+		}
+	}
+
+	op.Tags = append(op.Tags, r.Tags...)
+
+	if typ, ok := g.requestBodies[r.Name]; ok && typ != nil {
+		op.RequestBody = &RequestBody{
+			Content:  map[string]*MediaType{context.ContentJSONHeaderValue: {Schema: s.of(typ)}},
+			Required: true,
+		}
+	}
+
+	if responses, ok := g.responses[r.Name]; ok {
+		for statusCode, typ := range responses {
+			res := &Response{Description: http.StatusText(statusCode)}
+			if typ != nil {
+				res.Content = map[string]*MediaType{context.ContentJSONHeaderValue: {Schema: s.of(typ)}}
+			}
+			op.Responses[strconv.Itoa(statusCode)] = res
+		}
+	}
+
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+	}
+
+	return path, op
+}
+
+// pathParameters returns the OpenAPI path of the route, i.e /users/{id},
+// and its path parameters.
+func pathParameters(r *router.Route, s *schemas) (string, []*Parameter) {
+	var (
+		tmplParams = r.Tmpl().Params
+		params     []*Parameter
+		segments   = strings.Split(r.Path, "/")
+	)
+
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		wildcard := segment[0] == router.WildcardParamStart[0]
+		if segment[0] != router.ParamStart[0] && !wildcard {
+			continue
+		}
+
+		name := segment[1:]
+		if name == "" {
+			name = segment
+		}
+		segments[i] = "{" + name + "}"
+
+		param := &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		}
+
+		if wildcard {
+			param.Description = "Accepts any number of path segments"
+		}
+
+		for _, p := range tmplParams {
+			if p.Name == name {
+				param.Schema = paramSchema(p)
+				break
+			}
+		}
+
+		params = append(params, param)
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// templatedPathKey returns the "path" without its parameters' names, i.e /users/{},
+// the OpenAPI 3 paths with the same key are equivalent and they should be described once.
+func templatedPathKey(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// renameParameters renames the path parameters of the "op" from the names of the "path"
+// to the names of the equivalent "to" path, at the same positions.
+func renameParameters(op *Operation, path, to string) {
+	from, names := strings.Split(path, "/"), strings.Split(to, "/")
+	for i, segment := range from {
+		if segment == names[i] || !strings.HasPrefix(segment, "{") {
+			continue
+		}
+
+		name := strings.Trim(segment, "{}")
+		for _, p := range op.Parameters {
+			if p.In == "path" && p.Name == name {
+				p.Name = strings.Trim(names[i], "{}")
+			}
+		}
+	}
+}
+
+// mergeOperation merges the "src" operation, of a route with the same method and an equivalent path,
+// to the "dst" one, the path parameters with different schemas are described by a "oneOf" schema.
+func mergeOperation(dst, src *Operation) {
+	for _, p := range src.Parameters {
+		var existing *Parameter
+		for _, dstParam := range dst.Parameters {
+			if dstParam.In == p.In && dstParam.Name == p.Name {
+				existing = dstParam
+				break
+			}
+		}
+
+		if existing == nil {
+			dst.Parameters = append(dst.Parameters, p)
+			continue
+		}
+
+		existing.Schema = oneOfSchema(existing.Schema, p.Schema)
+		if existing.Description != p.Description {
+			existing.Description = ""
+		}
+	}
+
+	for code, res := range src.Responses {
+		if _, ok := dst.Responses[code]; !ok {
+			dst.Responses[code] = res
+		}
+	}
+
+	for _, tag := range src.Tags {
+		if !containsString(dst.Tags, tag) {
+			dst.Tags = append(dst.Tags, tag)
+		}
+	}
+
+	dst.Deprecated = dst.Deprecated && src.Deprecated
+}
+
+// oneOfSchema returns a schema that accepts either the "a" or the "b",
+// it returns the "a" if they are equal.
+func oneOfSchema(a, b *Schema) *Schema {
+	if reflect.DeepEqual(a, b) {
+		return a
+	}
+
+	if a.OneOf == nil {
+		a = &Schema{OneOf: []*Schema{a}}
+	}
+
+	for _, s := range a.OneOf {
+		if reflect.DeepEqual(s, b) {
+			return a
+		}
+	}
+
+	a.OneOf = append(a.OneOf, b)
+	return a
+}
+
+func containsString(slice []string, s string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// paramSchema returns the schema of a path parameter
+// based on its macro type and its functions, i.e {id:int min(1)}.
+func paramSchema(p macro.TemplateParam) *Schema {
+	var schema *Schema
+
+	switch p.Type {
+	case ast.ParamTypeInt:
+		schema = &Schema{Type: "integer", Format: "int64", Minimum: float(0)}
+	case ast.ParamTypeLong:
+		schema = &Schema{Type: "integer", Format: "int64", Minimum: float(0)}
+	case ast.ParamTypeBoolean:
+		schema = &Schema{Type: "boolean"}
+	case ast.ParamTypeAlphabetical:
+		schema = &Schema{Type: "string", Pattern: "^[a-zA-Z ]+$"}
+	case ast.ParamTypeFile:
+		schema = &Schema{Type: "string", Pattern: "^[a-zA-Z0-9_.-]*$"}
+	default:
+		schema = &Schema{Type: "string"}
+		if p.Type.IsCustom() {
+			// i.e "uuid".
+			schema.Format = p.Type.String()
+		}
+	}
+
+	stmt, err := parser.NewParamParser(p.Src).Parse()
+	if err != nil {
+		return schema
+	}
+
+	for _, fn := range stmt.Funcs {
+		paramFuncSchema(schema, fn)
+	}
+
+	return schema
+}
+
+// paramFuncSchema adds the constraints of a builtin param function, i.e min(1), to the "schema",
+// the rest param functions are added to the schema's description.
+func paramFuncSchema(schema *Schema, fn ast.ParamFunc) {
+	numeric := schema.Type == "integer"
+	intArg := func(i int) (int, bool) {
+		if len(fn.Args) <= i {
+			return 0, false
+		}
+		n, err := ast.ParamFuncArgToInt(fn.Args[i])
+		return n, err == nil
+	}
+	stringArg := func() (string, bool) {
+		if len(fn.Args) == 0 {
+			return "", false
+		}
+		s, ok := fn.Args[0].(string)
+		return s, ok
+	}
+
+	switch fn.Name {
+	case "min":
+		if n, ok := intArg(0); ok {
+			if numeric {
+				schema.Minimum = float(float64(n))
+			} else {
+				schema.MinLength = &n
+			}
+			return
+		}
+	case "max":
+		if n, ok := intArg(0); ok {
+			if numeric {
+				schema.Maximum = float(float64(n))
+			} else {
+				schema.MaxLength = &n
+			}
+			return
+		}
+	case "range":
+		min, okMin := intArg(0)
+		max, okMax := intArg(1)
+		if okMin && okMax && numeric {
+			schema.Minimum = float(float64(min))
+			schema.Maximum = float(float64(max))
+			return
+		}
+	case "regexp":
+		if expr, ok := stringArg(); ok {
+			schema.Pattern = expr
+			return
+		}
+	case "prefix":
+		if prefix, ok := stringArg(); ok {
+			schema.Pattern = "^" + regexp.QuoteMeta(prefix)
+			return
+		}
+	case "suffix":
+		if suffix, ok := stringArg(); ok {
+			schema.Pattern = regexp.QuoteMeta(suffix) + "$"
+			return
+		}
+	case "contains":
+		if s, ok := stringArg(); ok {
+			schema.Pattern = regexp.QuoteMeta(s)
+			return
+		}
+	}
+
+	// describe the custom param functions, i.e version(4).
+	args := make([]string, len(fn.Args))
+	for i, arg := range fn.Args {
+		switch v := arg.(type) {
+		case string:
+			args[i] = v
+		case int:
+			args[i] = strconv.Itoa(v)
+		}
+	}
+
+	if schema.Description != "" {
+		schema.Description += ", "
+	}
+	schema.Description += fn.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+var (
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	resultType = reflect.TypeOf((*methodfunc.Result)(nil)).Elem()
+)
+
+// controllerResponses returns the responses of a controller's method function
+// based on its results, as they're being dispatched by the `methodfunc.DispatchFuncResult`.
+func controllerResponses(info *methodfunc.FuncInfo, s *schemas) map[string]*Response {
+	var (
+		responses = make(map[string]*Response)
+		ok        = &Response{Description: http.StatusText(http.StatusOK)}
+		typ       = info.Type
+	)
+
+	for i, n := 0, typ.NumOut(); i < n; i++ {
+		out := typ.Out(i)
+
+		switch {
+		case out.Kind() == reflect.Bool:
+			// false fires a not found.
+			responses["404"] = &Response{Description: http.StatusText(http.StatusNotFound)}
+		case out.Kind() == reflect.Int:
+			// the status code, can't be resolved.
+		case out.Implements(errorType):
+			responses[strconv.Itoa(methodfunc.DefaultErrStatusCode)] = &Response{
+				Description: http.StatusText(methodfunc.DefaultErrStatusCode),
+				Content:     map[string]*MediaType{context.ContentTextHeaderValue: {Schema: &Schema{Type: "string"}}},
+			}
+		case out.Kind() == reflect.String || out == bytesType:
+			if ok.Content == nil { // the second string is the content type.
+				ok.Content = map[string]*MediaType{context.ContentTextHeaderValue: {Schema: &Schema{Type: "string"}}}
+			}
+		case out.Implements(resultType):
+			// it dispatches itself, i.e mvc.View, the content can't be resolved.
+		default:
+			ok.Content = map[string]*MediaType{context.ContentJSONHeaderValue: {Schema: s.of(out)}}
+		}
+	}
+
+	responses["200"] = ok
+	return responses
+}
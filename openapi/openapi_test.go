@@ -0,0 +1,184 @@
+// black-box testing
+package openapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kataras/iris"
+	"github.com/kataras/iris/context"
+	"github.com/kataras/iris/httptest"
+	"github.com/kataras/iris/mvc"
+	"github.com/kataras/iris/openapi"
+)
+
+type book struct {
+	ID     int64    `json:"id"`
+	Title  string   `json:"title"`
+	Tags   []string `json:"tags,omitempty"`
+	Author *author  `json:"author"`
+}
+
+type author struct {
+	Name string `json:"name"`
+}
+
+type booksController struct{ mvc.Controller }
+
+func (c *booksController) GetBy(id int64) (book, bool) {
+	return book{ID: id}, id > 0
+}
+
+func (c *booksController) PostBy(id int64) error {
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	app := iris.New()
+	noop := func(ctx context.Context) {}
+
+	app.Get("/users/{id:int min(1)}", noop).
+		Describe("Get a user", "").
+		Tag("users").
+		Deprecate(time.Time{}).Name = "getUser"
+	app.Get("/users/{id:int}/posts/{slug:string prefix(post_) max(20)}", noop)
+	app.Get("/files/{file:path}", noop)
+	app.Post("/users", noop).Name = "createUser"
+	app.None("/offline", noop)
+	app.Controller("/books", new(booksController))
+
+	docs := openapi.New(openapi.Config{Title: "test", Version: "1.0.0"}).
+		RequestBody("createUser", author{}).
+		Response("createUser", iris.StatusCreated, book{})
+
+	doc := docs.Generate(app.GetRoutes())
+
+	if expected, got := openapi.Version, doc.OpenAPI; expected != got {
+		t.Fatalf("expected openapi version %s but got %s", expected, got)
+	}
+
+	if expected, got := 5, len(doc.Paths); expected != got {
+		t.Fatalf("expected %d paths but got %d", expected, got)
+	}
+
+	getUser := (*doc.Paths["/users/{id}"])["get"]
+	if getUser == nil || getUser.OperationID != "getUser" {
+		t.Fatalf("expected the getUser operation but got %#v", getUser)
+	}
+	if getUser.Summary != "Get a user" || getUser.Tags[0] != "users" || !getUser.Deprecated {
+		t.Fatalf("expected the route's metadata on the getUser operation but got %#v", getUser)
+	}
+	if p := getUser.Parameters[0]; p.Name != "id" || p.In != "path" || p.Schema.Type != "integer" || p.Schema.Format != "int64" || *p.Schema.Minimum != 1 {
+		t.Fatalf("unexpected parameter for id: %#v", p.Schema)
+	}
+
+	posts := (*doc.Paths["/users/{id}/posts/{slug}"])["get"]
+	if p := posts.Parameters[1].Schema; p.Type != "string" || p.Pattern != "^post_" || *p.MaxLength != 20 {
+		t.Fatalf("unexpected parameter for slug: %#v", p)
+	}
+
+	createUser := (*doc.Paths["/users"])["post"]
+	if schema := createUser.RequestBody.Content["application/json"].Schema; schema.Ref != "#/components/schemas/author" {
+		t.Fatalf("unexpected request body schema: %#v", schema)
+	}
+	if res := createUser.Responses["201"]; res == nil || res.Content["application/json"].Schema.Ref != "#/components/schemas/book" {
+		t.Fatalf("unexpected response: %#v", res)
+	}
+
+	getBook := (*doc.Paths["/books/{paramfirst}"])["get"]
+	if getBook.OperationID != "booksController.GetBy" || getBook.Tags[0] != "booksController" {
+		t.Fatalf("unexpected controller operation: %#v", getBook)
+	}
+	if getBook.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/book" ||
+		getBook.Responses["404"] == nil {
+		t.Fatalf("unexpected controller responses: %#v", getBook.Responses)
+	}
+	if postBook := (*doc.Paths["/books/{paramfirst}"])["post"]; postBook.Responses["400"] == nil {
+		t.Fatalf("expected an error response for the controller's method function but got %#v", postBook.Responses)
+	}
+
+	bookSchema := doc.Components.Schemas["book"]
+	if expected, got := 4, len(bookSchema.Properties); expected != got {
+		t.Fatalf("expected %d properties for book schema but got %d", expected, got)
+	}
+	if expected, got := []string{"id", "title"}, bookSchema.Required; len(got) != 2 || got[0] != expected[0] || got[1] != expected[1] {
+		t.Fatalf("expected required properties %v but got %v", expected, got)
+	}
+}
+
+func TestGenerateEquivalentPaths(t *testing.T) {
+	app := iris.New()
+	noop := func(ctx context.Context) {}
+
+	app.Get("/users/{id:int}", noop)
+	app.Get("/users/{username:string}", noop)
+	app.Delete("/users/{username:alphabetical}", noop)
+
+	doc := openapi.New(openapi.Config{Title: "test", Version: "1.0.0"}).Generate(app.GetRoutes())
+
+	if expected, got := 1, len(doc.Paths); expected != got {
+		t.Fatalf("expected %d path but got %d: %v", expected, got, doc.Paths)
+	}
+
+	item := doc.Paths["/users/{id}"]
+	if item == nil {
+		t.Fatalf("expected the path /users/{id} but got %v", doc.Paths)
+	}
+
+	get := (*item)["get"]
+	if expected, got := 1, len(get.Parameters); expected != got {
+		t.Fatalf("expected %d parameter but got %d", expected, got)
+	}
+	if p := get.Parameters[0]; p.Name != "id" || len(p.Schema.OneOf) != 2 ||
+		p.Schema.OneOf[0].Type != "integer" || p.Schema.OneOf[1].Type != "string" {
+		t.Fatalf("expected a oneOf schema of an integer and a string but got %#v", p.Schema)
+	}
+
+	if p := (*item)["delete"].Parameters[0]; p.Name != "id" || p.Schema.Pattern != "^[a-zA-Z ]+$" {
+		t.Fatalf("expected the renamed alphabetical parameter but got %#v", p)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	app := iris.New()
+	app.Get("/users/{id:int}", func(ctx context.Context) {})
+
+	docs := openapi.New(openapi.Config{Title: "test", Version: "1.0.0"})
+	app.Get("/openapi.json", docs.Handler(app.APIBuilder))
+	app.Get("/openapi.yaml", docs.Handler(app.APIBuilder))
+
+	e := httptest.New(t, app)
+	e.GET("/openapi.json").Expect().Status(httptest.StatusOK).
+		JSON().Object().ValueEqual("openapi", openapi.Version).
+		Value("paths").Object().ContainsKey("/users/{id}")
+	e.GET("/openapi.yaml").Expect().Status(httptest.StatusOK).
+		Body().Contains("openapi: 3.0.0").Contains("/users/{id}:")
+}
+
+func TestGenerateHost(t *testing.T) {
+	app := iris.New()
+	noop := func(ctx context.Context) {}
+
+	app.Get("/users", noop).Name = "listUsers"
+	api := app.Host("api.example.com")
+	api.Get("/users", noop)
+	api.Version("1.0.0").Get("/users/{id:int}", noop).Produce("application/json")
+
+	doc := openapi.New(openapi.Config{Title: "test", Version: "1.0.0"}).Generate(app.GetRoutes())
+	if expected, got := 1, len(doc.Paths); expected != got {
+		t.Fatalf("expected %d path of the root domain but got %d: %v", expected, got, doc.Paths)
+	}
+	if op := (*doc.Paths["/users"])["get"]; op.OperationID != "listUsers" {
+		t.Fatalf("expected the listUsers operation but got %#v", op)
+	}
+
+	doc = openapi.New(openapi.Config{Title: "test", Version: "1.0.0", Host: "api.example.com"}).Generate(app.GetRoutes())
+	if expected, got := 2, len(doc.Paths); expected != got {
+		t.Fatalf("expected %d paths of the host but got %d: %v", expected, got, doc.Paths)
+	}
+	for path, item := range doc.Paths {
+		if op := (*item)["get"]; op.OperationID != "" {
+			t.Fatalf("expected no operationId for the default route name of %s but got %s", path, op.OperationID)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schemaRefPrefix is the prefix of the references to the components' schemas.
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType           = reflect.TypeOf(time.Time{})
+	bytesType          = reflect.TypeOf([]byte{})
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// schemas builds the schemas of Go types,
+// the named struct types are kept as components and they are referenced by their name.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// of returns the schema of the "typ".
+func (s *schemas) of(typ reflect.Type) *Schema {
+	nullable := false
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+		nullable = true
+	}
+
+	schema := s.build(typ)
+	if nullable && schema.Ref == "" {
+		schema.Nullable = true
+	}
+	return schema
+}
+
+func (s *schemas) build(typ reflect.Type) *Schema {
+	switch {
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case typ == bytesType:
+		return &Schema{Type: "string", Format: "byte"}
+	case typ != emptyInterfaceType && typ.Kind() != reflect.Interface && typ.Implements(textMarshalerType):
+		// encoded as text, i.e uuid.UUID.
+		return &Schema{Type: "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Minimum: float(0)}
+	case reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.of(typ.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(typ.Elem())}
+	case reflect.Struct:
+		if typ.Name() == "" { // anonymous struct, inline.
+			return s.object(typ)
+		}
+		return &Schema{Ref: schemaRefPrefix + s.component(typ)}
+	default:
+		// interface{} and the rest, any type.
+		return &Schema{}
+	}
+}
+
+// component registers the named struct "typ" to the components, once,
+// and returns its name.
+func (s *schemas) component(typ reflect.Type) string {
+	if name, ok := s.names[typ]; ok {
+		return name
+	}
+
+	name := typ.Name()
+	if _, exists := s.components[name]; exists {
+		// same name from a different package.
+		for i := 2; ; i++ {
+			if _, exists = s.components[name+strconv.Itoa(i)]; !exists {
+				name += strconv.Itoa(i)
+				break
+			}
+		}
+	}
+
+	// register the name first, the struct may reference itself.
+	s.names[typ] = name
+	s.components[name] = nil // reserve the name.
+	s.components[name] = s.object(typ)
+	return name
+}
+
+// object returns the "object" schema of a struct type,
+// its properties are named after their json tags (if any).
+func (s *schemas) object(typ reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.fields(schema, typ)
+	return schema
+}
+
+func (s *schemas) fields(schema *Schema, typ reflect.Type) {
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported.
+			continue
+		}
+
+		name, omitempty, skip := jsonFieldName(f)
+		if skip {
+			continue
+		}
+
+		fieldTyp := f.Type
+		if f.Anonymous && name == "" {
+			// embedded struct without a json name, its fields are promoted.
+			for fieldTyp.Kind() == reflect.Ptr {
+				fieldTyp = fieldTyp.Elem()
+			}
+			if fieldTyp.Kind() == reflect.Struct {
+				s.fields(schema, fieldTyp)
+				continue
+			}
+		}
+
+		if f.PkgPath != "" { // unexported and not a struct.
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		schema.Properties[name] = s.of(f.Type)
+		if !omitempty && f.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// jsonFieldName returns the name of the field from its json tag, if any,
+// and reports whether it's omitted when empty or it should be skipped entirely.
+func jsonFieldName(f reflect.StructField) (name string, omitempty bool, skip bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return
+}
+
+func float(f float64) *float64 {
+	return &f
+}